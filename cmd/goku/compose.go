@@ -0,0 +1,23 @@
+package main
+
+import "github.com/AnthonyHewins/goku/pkg/goku"
+
+var compositions = []decoration{
+	{"--tee", "Call every one of a list of implementations on each call", goku.StructContract.GenTeeComposite},
+	{"--fallback", "Try a list of implementations in order until one succeeds", goku.StructContract.GenFallbackComposite},
+	{"--shadow", "Serve calls from a primary, and check a candidate gives the same results in the background", goku.StructContract.GenShadowComposite},
+}
+
+var compose = &decorateCmd{
+	cmd:     "compose",
+	summary: "Generate an implementation of a struct's interface built from several others",
+	intro: `Generate a composite implementation of the interface of a struct.
+
+Pass in the name of a struct and the kind of composite you want. The source code
+in the directory specified (default is current dir, unless overrided by -d/--dir)
+will be scanned for the struct's methods, and a type implementing its interface
+(generated by "goku iface") will be generated. It's built from a list of other
+implementations of the interface.`,
+	kinds: compositions,
+	dir:   ".",
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/AnthonyHewins/goku/pkg/goku"
+)
+
+// A kind of decorator the decorate command can generate
+type decoration struct {
+	flag, desc string
+	gen        func(goku.StructContract, string, ...goku.DecoratorOpt) ([]byte, error)
+}
+
+var decorations = []decoration{
+	{"--log", "Log every call through a *slog.Logger", goku.StructContract.GenLogDecorator},
+	{"--trace", "Start an OpenTelemetry span around every call", goku.StructContract.GenTraceDecorator},
+	{"--metrics", "Count calls and errors, and time calls, through a generated Metrics interface", goku.StructContract.GenMetricsDecorator},
+	{"--retry", "Retry calls that return an error with exponential backoff", goku.StructContract.GenRetryDecorator},
+	{"--breaker", "Fail fast with circuit breakers while calls keep failing", goku.StructContract.GenBreakerDecorator},
+	{"--timeout", "Give calls that take a context a deadline", goku.StructContract.GenTimeoutDecorator},
+	{"--sync", "Hold a lock around every call, so implementations can be shared between goroutines", goku.StructContract.GenSyncDecorator},
+	{"--cache", "Cache results of methods with a //goku:cache directive, keyed by their arguments", goku.StructContract.GenCacheDecorator},
+	{"--limit", "Limit the rate of calls and how many run at once", goku.StructContract.GenLimitDecorator},
+	{"--record", "Record every call onto a JSON cassette", goku.StructContract.GenRecordDecorator},
+	{"--replay", "Implement the interface by replaying a cassette --record recorded", goku.StructContract.GenReplayDecorator},
+	{"--recover", "Recover panics, returning them as errors where methods can", goku.StructContract.GenRecoverDecorator},
+	{"--hooks", "Call hooks before and after every call, and let each method be intercepted", goku.StructContract.GenHooksDecorator},
+	{"--faults", "Inject errors, latency and panics into calls by a set of rules", goku.StructContract.GenFaultsDecorator},
+	{"--singleflight", "Collapse concurrent identical calls of methods with a //goku:singleflight directive into one", goku.StructContract.GenSingleflightDecorator},
+	{"--batch", "Group concurrent calls of methods with a //goku:batch directive into calls that load many", goku.StructContract.GenBatchDecorator},
+	{"--wrap", "Wrap returned errors with the method and arguments of the call", goku.StructContract.GenWrapDecorator},
+	{"--authz", "Check callers are allowed to make each call, by the roles in //goku:authz directives", goku.StructContract.GenAuthzDecorator},
+}
+
+// decorateCmd generates one of a table of kinds of wrappers around a struct's
+// interface. It backs both decorate and compose
+type decorateCmd struct {
+	cmd, summary, intro string
+	kinds               []decoration
+
+	dir       string
+	ifaceName string
+	out       string
+}
+
+var decorate = &decorateCmd{
+	cmd:     "decorate",
+	summary: "Generate a decorator around a struct's interface",
+	intro: `Generate a decorator for the interface of a struct.
+
+Pass in the name of a struct and the kind of decorator you want. The source code
+in the directory specified (default is current dir, unless overrided by -d/--dir)
+will be scanned for the struct's methods, and a wrapper implementing its interface
+(generated by "goku iface") will be generated. The wrapper delegates to any other
+implementation of the interface.`,
+	kinds: decorations,
+	dir:   ".",
+}
+
+func (d decorateCmd) name() string { return d.cmd }
+
+func (d decorateCmd) usage() string { return `STRUCTNAME --KIND [FLAGS]` }
+
+func (d decorateCmd) short() string { return d.summary }
+
+func (d decorateCmd) long() string {
+	base := d.intro + "\n\nKinds (exactly one)"
+	for _, v := range d.kinds {
+		base += fmt.Sprintf("\n%27s\t%s", bold.Sprint(v.flag), gray.Sprint(v.desc))
+	}
+
+	base += "\n\nFlags"
+	for _, v := range [...][2]string{
+		{"-h, --help", "Display help text for this command"},
+		{"-d, --dir STRING", "Scan this dir for the struct"},
+		{"-i, --iface STRING", `Name of the interface to implement (defaults to STRUCTNAME+"Interface")`},
+		{"-n, --name STRING", "Override the decorator's name. Defaults depend on the kind"},
+		{"-p, --pkg STRING", "Override the package name. By default, it uses the package of the struct"},
+		{"--private", "Include private methods"},
+		{"--rw", "With --sync, use a sync.RWMutex and let methods that only read share it"},
+		{"-o, --out", "Don't generate to stdout"},
+	} {
+		base += fmt.Sprintf("\n%27s\t%s", bold.Sprint(v[0]), gray.Sprint(v[1]))
+	}
+
+	return base
+}
+
+func (d *decorateCmd) run(args argSlice) error {
+	structName := args.shift()
+	switch structName {
+	case "":
+		return fmt.Errorf("not enough args: supply the name of the type")
+	case "-h", "help", "--help":
+		fmt.Println(d.long())
+		return nil
+	}
+
+	var kind *decoration
+	rw := false
+	opts := make([]goku.DecoratorOpt, 0, 5)
+	for flag := args.nextFlag(); flag != ""; flag = args.nextFlag() {
+		switch flag {
+		case "-d", "--dir":
+			if d.dir = args.shift(); d.dir == "" {
+				return fmt.Errorf("missing argument for dir")
+			}
+		case "-i", "--iface":
+			if d.ifaceName = args.shift(); d.ifaceName == "" {
+				return fmt.Errorf("missing argument for interface name")
+			}
+		case "-n", "--name":
+			n := args.shift()
+			if n == "" {
+				return fmt.Errorf("missing argument for decorator name")
+			}
+			opts = append(opts, goku.DecoratorName(n))
+		case "-p", "--pkg":
+			p := args.shift()
+			if p == "" {
+				return fmt.Errorf("missing argument for package override flag")
+			}
+			opts = append(opts, goku.DecoratorPkg(p))
+		case "--private":
+			opts = append(opts, goku.DecoratePrivate())
+		case "--rw":
+			rw = true
+			opts = append(opts, goku.ReadWriteLock())
+		case "-o", "--out":
+			if d.out = args.shift(); d.out == "" {
+				return fmt.Errorf("missing arg for output file")
+			}
+		default:
+			found := false
+			for i, v := range d.kinds {
+				if v.flag != flag {
+					continue
+				}
+
+				if kind != nil {
+					return fmt.Errorf("only one kind can be generated at a time, got %s and %s", kind.flag, flag)
+				}
+				kind, found = &d.kinds[i], true
+			}
+
+			if !found {
+				return fmt.Errorf("unknown flag/option %s", flag)
+			}
+		}
+	}
+
+	if kind == nil {
+		return fmt.Errorf("missing the kind to generate, see goku help %s", d.name())
+	}
+
+	if rw && kind.flag != "--sync" {
+		return fmt.Errorf("--rw only applies to --sync")
+	}
+
+	if d.ifaceName == "" {
+		d.ifaceName = structName + "Interface"
+	}
+
+	files, err := goFiles(d.dir)
+	if err != nil {
+		return err
+	}
+
+	x := goku.NewStructInfoGen(structName)
+	if err = x.AddFile(files...); err != nil {
+		return err
+	}
+
+	s, err := x.StructInfo()
+	if err != nil {
+		return err
+	}
+
+	source, err := kind.gen(*s, d.ifaceName, opts...)
+	if err != nil {
+		return err
+	}
+
+	return writeOut(d.out, source)
+}
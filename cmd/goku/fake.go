@@ -0,0 +1,109 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/AnthonyHewins/goku/pkg/goku"
+)
+
+type fakeCmd struct {
+	dir      string
+	fakeName string
+	out      string
+}
+
+var fake = &fakeCmd{dir: "."}
+
+func (f fakeCmd) name() string { return "fake" }
+
+func (f fakeCmd) usage() string { return `FUNCTYPE [FLAGS]` }
+
+func (f fakeCmd) short() string { return "Generate a recording fake for a function type" }
+
+func (f fakeCmd) long() string {
+	base := `Generate a recording fake for a named function type.
+
+Pass in the name of a function type, e.g. "type Retrier func(ctx context.Context, attempt int) error",
+and the source code in the directory specified (default is current dir, unless
+overrided by -d/--dir) will be scanned for it. The fake's Call method matches the
+signature, so fake.Call can be passed anywhere the function type is expected. It
+records every call and returns whatever is configured with Returns/ReturnsOnCall,
+or delegates to Stub if it's set.
+
+Flags`
+
+	for _, v := range [...][2]string{
+		{"-h, --help", "Display help text for this command"},
+		{"-d, --dir STRING", "Scan this dir for the function type"},
+		{"-n, --name STRING", `Override the fake's name with this name (defaults to "Fake"+FUNCTYPE)`},
+		{"-p, --pkg STRING", "Override the package name. By default, it uses the package of the function type"},
+		{"-o, --out", "Don't generate to stdout"},
+	} {
+		base += fmt.Sprintf("\n%27s\t%s", bold.Sprint(v[0]), gray.Sprint(v[1]))
+	}
+
+	return base
+}
+
+func (f *fakeCmd) run(args argSlice) error {
+	typeName := args.shift()
+	switch typeName {
+	case "":
+		return fmt.Errorf("not enough args: supply the name of the function type")
+	case "-h", "help", "--help":
+		fmt.Println(f.long())
+		return nil
+	}
+
+	var opts []goku.FakeOpt
+	for flag := args.nextFlag(); flag != ""; flag = args.nextFlag() {
+		switch flag {
+		case "-d", "--dir":
+			if f.dir = args.shift(); f.dir == "" {
+				return fmt.Errorf("missing argument for dir")
+			}
+		case "-n", "--name":
+			if f.fakeName = args.shift(); f.fakeName == "" {
+				return fmt.Errorf("missing argument for fake name")
+			}
+		case "-p", "--pkg":
+			p := args.shift()
+			if p == "" {
+				return fmt.Errorf("missing argument for package override flag")
+			}
+			opts = append(opts, goku.OverrideFakePkg(p))
+		case "-o", "--out":
+			if f.out = args.shift(); f.out == "" {
+				return fmt.Errorf("missing arg for output file")
+			}
+		default:
+			return fmt.Errorf("unknown flag/option %s", flag)
+		}
+	}
+
+	if f.fakeName == "" {
+		f.fakeName = "Fake" + typeName
+	}
+
+	files, err := goFiles(f.dir)
+	if err != nil {
+		return err
+	}
+
+	x := goku.NewFuncTypeGen(typeName)
+	if err = x.AddFile(files...); err != nil {
+		return err
+	}
+
+	c, err := x.FuncContract()
+	if err != nil {
+		return err
+	}
+
+	source, err := c.GenFake(f.fakeName, opts...)
+	if err != nil {
+		return err
+	}
+
+	return writeOut(f.out, source)
+}
@@ -2,8 +2,6 @@ package main
 
 import (
 	"fmt"
-	"os"
-	"path/filepath"
 	"strings"
 
 	"github.com/AnthonyHewins/goku/pkg/goku"
@@ -14,6 +12,7 @@ type ifaceCmd struct {
 	dir       string
 	ifaceName string
 	out       string
+	mockOut   string
 }
 
 var iface = &ifaceCmd{dir: "."}
@@ -38,6 +37,7 @@ Flags`
 		{"-h, --help", "Display help text for this command"},
 		{"-d, --dir STRING", "Scan this dir for the struct"},
 		{"-m, --mock STRING", "Generate a mock implementation also"},
+		{"--mock-out STRING", "Write the mock to this file. With -o it defaults to OUT_mock_test.go"},
 		{"-n, --name STRING", `Override the interface name with this name (defaults to STRUCTNAME+"Interface`},
 		{"-p, --pkg STRING", "Override the package name. By default, it uses the package of the struct"},
 		{"--private", "Include private methods"},
@@ -59,6 +59,7 @@ func (i *ifaceCmd) run(args argSlice) error {
 		return nil
 	}
 
+	var mock string
 	opts := make([]goku.IfaceOpt, 0, 15)
 	for flag := args.nextFlag(); flag != ""; flag = args.nextFlag() {
 		switch flag {
@@ -67,11 +68,13 @@ func (i *ifaceCmd) run(args argSlice) error {
 				return fmt.Errorf("missing argument for dir")
 			}
 		case "-m", "--mock":
-			mock := args.shift()
-			if mock == "" {
+			if mock = args.shift(); mock == "" {
 				return fmt.Errorf("missing argument for mock")
 			}
-			opts = append(opts, goku.GenMock(mock))
+		case "--mock-out":
+			if i.mockOut = args.shift(); i.mockOut == "" {
+				return fmt.Errorf("missing argument for mock output file")
+			}
 		case "-n", "--name":
 			if i.ifaceName = args.shift(); i.ifaceName == "" {
 				return fmt.Errorf("missing argument for interface name")
@@ -93,26 +96,22 @@ func (i *ifaceCmd) run(args argSlice) error {
 		}
 	}
 
+	if i.mockOut != "" && mock == "" {
+		return fmt.Errorf("--mock-out needs a mock to generate with -m/--mock")
+	}
+
 	if i.ifaceName == "" {
 		i.ifaceName = structName + "Interface"
 	}
 
-	entries, err := os.ReadDir(i.dir)
+	files, err := goFiles(i.dir)
 	if err != nil {
 		return err
 	}
 
 	x := goku.NewStructInfoGen(structName)
-	for _, entry := range entries {
-		if entry.IsDir() {
-			continue
-		}
-
-		if name := entry.Name(); strings.HasSuffix(name, ".go") && !strings.HasSuffix(name, "_test.go") {
-			if err := x.AddFile(filepath.Join(i.dir, name)); err != nil {
-				return err
-			}
-		}
+	if err = x.AddFile(files...); err != nil {
+		return err
 	}
 
 	s, err := x.StructInfo()
@@ -120,25 +119,27 @@ func (i *ifaceCmd) run(args argSlice) error {
 		return err
 	}
 
+	// mocks import testing, so keep them out of the build next to the interface
+	if mock != "" && i.mockOut == "" && i.out != "" {
+		i.mockOut = strings.TrimSuffix(i.out, ".go") + "_mock_test.go"
+	}
+
+	if mock != "" && i.mockOut == "" {
+		opts = append(opts, goku.GenMock(mock))
+	}
+
 	source, err := s.GenInterface(i.ifaceName, opts...)
 	if err != nil {
 		return err
 	}
 
-	w := os.Stdout
-	if i.out != "" {
-		f, err := os.Create(i.out)
-		if err != nil {
-			return err
-		}
-		defer f.Close()
-		w = f
+	if err = writeOut(i.out, source); err != nil || i.mockOut == "" {
+		return err
 	}
 
-	if _, err = w.Write([]byte(preamble())); err != nil {
+	if source, err = s.GenMock(i.ifaceName, mock, opts...); err != nil {
 		return err
 	}
 
-	_, err = w.Write(source)
-	return err
+	return writeOut(i.mockOut, source)
 }
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 )
 
@@ -14,7 +15,7 @@ var version = "unknown"
 
 var l = logger{os.Stderr}
 
-var commands = []command{help, iface, versionCmd{}}
+var commands = []command{help, iface, fake, decorate, compose, versionCmd{}}
 
 type command interface {
 	name() string
@@ -137,6 +138,47 @@ func main() {
 	}
 }
 
+// goFiles lists the non-test go files in dir
+func goFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		if name := entry.Name(); strings.HasSuffix(name, ".go") && !strings.HasSuffix(name, "_test.go") {
+			files = append(files, filepath.Join(dir, name))
+		}
+	}
+
+	return files, nil
+}
+
+// writeOut writes generated source to the file out, or stdout if it's empty
+func writeOut(out string, source []byte) error {
+	w := os.Stdout
+	if out != "" {
+		f, err := os.Create(out)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	if _, err := w.Write([]byte(preamble())); err != nil {
+		return err
+	}
+
+	_, err := w.Write(source)
+	return err
+}
+
 func preamble() string {
 	return fmt.Sprintf(`// Code generated by goku; DO NOT EDIT
 // Version: %s
@@ -0,0 +1,21 @@
+package goku
+
+// locals the fallback template declares
+var fallbackReserved = []string{"composite", "i"}
+
+// Generate a composite implementation of the interface named iface that
+// tries a list of implementations in order, returning the first result that
+// isn't an error, e.g. a cache, then a database, then a replica. A classifier
+// decides which errors fall through to the next implementation. Methods that
+// don't end in an error can't fail, so only the first implementation is
+// called for them
+func (s StructContract) GenFallbackComposite(iface string, opts ...DecoratorOpt) ([]byte, error) {
+	d := s.newDecorator(iface, "Fallback", opts)
+	d.use(decorrtPath)
+
+	if err := d.addMethods(s, fallbackReserved, nil); err != nil {
+		return nil, err
+	}
+
+	return d.render("fallback.go.tmpl")
+}
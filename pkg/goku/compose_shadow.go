@@ -0,0 +1,65 @@
+package goku
+
+import (
+	"fmt"
+	"strings"
+)
+
+// locals the shadow template declares
+var shadowReserved = []string{"composite"}
+
+// Generate a composite implementation of the interface named iface that
+// serves every call from a primary implementation, while calling a candidate
+// in the background with the same arguments, e.g. to check a rewrite against
+// the code it replaces. Results are compared by generated per-method
+// comparators, and mismatches reported to a callback. Methods that mustn't be
+// called twice, such as writes, opt out with a directive:
+//
+//	//goku:noshadow
+//	func (x *X) Save(ctx context.Context, u User) error
+func (s StructContract) GenShadowComposite(iface string, opts ...DecoratorOpt) ([]byte, error) {
+	d := s.newDecorator(iface, "Shadow", opts)
+	d.use("context", "reflect", decorrtPath)
+	d.declares("Wait")
+
+	err := d.addMethods(s, shadowReserved, func(m *decoratedMethod) error {
+		taken := map[string]struct{}{}
+		for _, v := range append(m.Params, m.Results...) {
+			taken[v.Name] = struct{}{}
+		}
+
+		var args, primary, locals, pParams, cParams, equal []string
+		for _, v := range m.Args() {
+			args = append(args, v.Name)
+		}
+
+		for idx, v := range m.Results {
+			local := freeName(fmt.Sprintf("c%d", idx), taken)
+			primary, locals = append(primary, v.Name), append(locals, local)
+
+			p, c := fmt.Sprintf("p%d", idx), fmt.Sprintf("c%d", idx)
+			pParams, cParams = append(pParams, p+" "+v.Type), append(cParams, c+" "+v.Type)
+			if v.Name == m.Err {
+				equal = append(equal, fmt.Sprintf("decorrt.SameError(%s, %s)", p, c))
+			} else {
+				equal = append(equal, fmt.Sprintf("reflect.DeepEqual(%s, %s)", p, c))
+			}
+		}
+
+		m.Vars = map[string]string{
+			"args":      anySlice(args),
+			"primary":   "[]any{" + strings.Join(primary, ", ") + "}",
+			"locals":    strings.Join(locals, ", "),
+			"candidate": "[]any{" + strings.Join(locals, ", ") + "}",
+			"params":    strings.Join(append(pParams, cParams...), ", "),
+			"equal":     strings.Join(equal, " && "),
+			"compare":   "equal" + m.Name + "(" + strings.Join(primary, ", ") + ", " + strings.Join(locals, ", ") + ")",
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return d.render("shadow.go.tmpl")
+}
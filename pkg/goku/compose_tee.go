@@ -0,0 +1,45 @@
+package goku
+
+import (
+	"fmt"
+	"strings"
+)
+
+// locals the tee template declares
+var teeReserved = []string{"composite", "i"}
+
+// Generate a composite implementation of the interface named iface that
+// calls every one of a list of implementations on each call, e.g. to write to
+// two stores at once. Results come from the first implementation, and which
+// error is returned is up to a decorrt.TeePolicy
+func (s StructContract) GenTeeComposite(iface string, opts ...DecoratorOpt) ([]byte, error) {
+	d := s.newDecorator(iface, "Tee", opts)
+	d.use(decorrtPath)
+
+	err := d.addMethods(s, teeReserved, func(m *decoratedMethod) error {
+		taken := map[string]struct{}{}
+		for _, v := range append(m.Params, m.Results...) {
+			taken[v.Name] = struct{}{}
+		}
+
+		locals := make([]string, len(m.Results))
+		var firsts []string
+		for idx, v := range m.Results {
+			locals[idx] = freeName(fmt.Sprintf("v%d", idx), taken)
+			if v.Name != m.Err {
+				firsts = append(firsts, v.Name+" = "+locals[idx])
+			}
+		}
+
+		m.Vars = map[string]string{"locals": strings.Join(locals, ", "), "firsts": strings.Join(firsts, "\n")}
+		if m.Err != "" {
+			m.Vars["err"] = locals[len(locals)-1]
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return d.render("tee.go.tmpl")
+}
@@ -0,0 +1,75 @@
+package goku
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// locals the authorization template declares
+var authzReserved = []string{"decorator", "err"}
+
+// Generate a decorator that checks callers of the interface named iface are
+// allowed to call each method before delegating, with a decorrt.Authorizer
+// that finds who's calling from the method's context. Methods name the roles
+// allowed to call them in a directive:
+//
+//	//goku:authz role=admin,editor
+//	func (x *X) Delete(ctx context.Context, id int) error
+//
+// Methods without one are allowed or denied by the Authorizer's default.
+// Denied calls return a *decorrt.ForbiddenError through their error result,
+// so methods without one are rejected: they'd have no way to report a denial.
+// They, or any other method, can be left unchecked on purpose with
+// //goku:noauthz. The decorator also lists every method's policy, for a report
+func (s StructContract) GenAuthzDecorator(iface string, opts ...DecoratorOpt) ([]byte, error) {
+	d := s.newDecorator(iface, "Authz", opts)
+	d.use("context", decorrtPath)
+	d.declares("Report")
+
+	err := d.addMethods(s, authzReserved, func(m *decoratedMethod) error {
+		directive, ok := m.Directive("authz")
+		switch {
+		case m.HasDirective("noauthz") && ok:
+			return errors.New("//goku:noauthz and //goku:authz contradict each other")
+		case m.HasDirective("noauthz"):
+			m.Vars = map[string]string{"exempt": "true"}
+			return nil
+		case !ok && m.Err == "":
+			return errors.New("can't report a denial without an error result; mark it //goku:noauthz to leave it unchecked")
+		case !ok:
+			return nil
+		}
+
+		if m.Ctx == "" || m.Err == "" {
+			return errors.New("//goku:authz needs the method to take a context.Context first and return an error last")
+		}
+
+		var roles []string
+		for k, v := range directive.Options() {
+			if k != "role" {
+				return fmt.Errorf("//goku:authz doesn't know %s, only role", k)
+			}
+
+			for _, role := range strings.Split(v, ",") {
+				if role == "" {
+					return fmt.Errorf("//goku:authz needs roles, e.g. role=admin,editor, got %q", directive.Args)
+				}
+				roles = append(roles, strconv.Quote(role))
+			}
+		}
+
+		if len(roles) == 0 {
+			return errors.New("//goku:authz needs roles, e.g. role=admin,editor")
+		}
+
+		m.Vars = map[string]string{"roles": strings.Join(roles, ", ")}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return d.render("authz.go.tmpl")
+}
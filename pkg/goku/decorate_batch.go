@@ -0,0 +1,110 @@
+package goku
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// locals the batching template declares
+var batchReserved = []string{"decorator"}
+
+// How long a batch waits for more keys unless its directive says otherwise
+const defaultBatchWait = time.Millisecond
+
+// Generate a decorator that groups concurrent calls to a method of the
+// interface named iface that loads one thing into calls to a method that
+// loads many, e.g. to avoid N+1 queries. The method that loads one names its
+// pair in a directive, optionally with how long a batch waits for more keys
+// and how many it holds at most:
+//
+//	//goku:batch GetMany wait=2ms size=100
+//	func (x *X) Get(ctx context.Context, id int) (User, error)
+//
+//	func (x *X) GetMany(ctx context.Context, ids []int) ([]User, error)
+//
+// The method that loads many must return a value for every key, in order
+func (s StructContract) GenBatchDecorator(iface string, opts ...DecoratorOpt) ([]byte, error) {
+	d := s.newDecorator(iface, "Batching", opts)
+	d.use("context", "time", decorrtPath)
+
+	err := d.addMethods(s, batchReserved, func(m *decoratedMethod) error {
+		directive, ok := m.Directive("batch")
+		if !ok {
+			return nil
+		}
+
+		fields := directive.Fields()
+		if len(fields) == 0 {
+			return errors.New("//goku:batch needs the method that loads many, e.g. //goku:batch GetMany")
+		}
+
+		if m.Ctx == "" || m.Err == "" || len(m.Args()) != 1 || len(m.Values()) != 1 || strings.HasPrefix(m.Args()[0].Type, "...") {
+			return errors.New("//goku:batch needs the method to look like Get(context.Context, K) (V, error)")
+		}
+
+		name, key, value := fields[0], m.Args()[0].Type, m.Values()[0].Type
+		many, ok := findMethod(s, name)
+		if !ok {
+			return fmt.Errorf("//goku:batch %s: no such method", name)
+		}
+
+		var spread bool
+		switch {
+		case len(many.Arguments) != 2 || many.ContextArg() == "",
+			len(many.Returns) != 2 || many.Returns[0] != "[]"+value || many.Returns[1] != "error":
+			return fmt.Errorf("//goku:batch %s needs it to look like %s(context.Context, []%s) ([]%s, error)", name, name, key, value)
+		case many.Arguments[1].Type == "..."+key:
+			spread = true
+		case many.Arguments[1].Type != "[]"+key:
+			return fmt.Errorf("//goku:batch %s takes %s, not []%s", name, many.Arguments[1].Type, key)
+		}
+
+		options := directive.Options()
+		wait := defaultBatchWait
+		if v, ok := options["wait"]; ok {
+			var err error
+			if wait, err = time.ParseDuration(v); err != nil || wait < 0 {
+				return fmt.Errorf("//goku:batch wait must be a duration, e.g. wait=2ms, got %q", v)
+			}
+		}
+
+		size := 0
+		if v, ok := options["size"]; ok {
+			var err error
+			if size, err = strconv.Atoi(v); err != nil || size < 1 {
+				return fmt.Errorf("//goku:batch size must be a positive integer, got %q", v)
+			}
+		}
+
+		m.Vars = map[string]string{
+			"batcher": "batch" + m.Name,
+			"key":     key,
+			"value":   value,
+			"many":    name,
+			"wait":    durationExpr(wait),
+			"size":    strconv.Itoa(size),
+		}
+		if spread {
+			m.Vars["spread"] = "true"
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return d.render("batch.go.tmpl")
+}
+
+// findMethod finds the method of s named name
+func findMethod(s StructContract, name string) (MethodInfo, bool) {
+	for _, v := range s.Methods {
+		if v.Name == name {
+			return v, true
+		}
+	}
+	return MethodInfo{}, false
+}
@@ -0,0 +1,28 @@
+package goku
+
+import "errors"
+
+// locals the breaker template declares
+var breakerReserved = []string{"decorator"}
+
+// Generate a decorator that guards calls to the interface named iface with
+// circuit breakers, either one per method or one shared by all of them. While
+// a breaker is open, calls fail with decorrt.ErrCircuitOpen through the
+// method's error result, so every method must end in an error
+func (s StructContract) GenBreakerDecorator(iface string, opts ...DecoratorOpt) ([]byte, error) {
+	d := s.newDecorator(iface, "Breaker", opts)
+	d.use(decorrtPath)
+	d.declares("Breaker")
+
+	err := d.addMethods(s, breakerReserved, func(m *decoratedMethod) error {
+		if m.Err == "" {
+			return errors.New("can't be guarded by a circuit breaker because its last result isn't an error")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return d.render("breaker.go.tmpl")
+}
@@ -0,0 +1,183 @@
+package goku
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// locals the caching template declares
+var cacheReserved = []string{"decorator", "key", "entry", "ok"}
+
+// Entries each method's cache holds unless its directive says otherwise
+const defaultCacheSize = 1024
+
+// Generate a decorator that memoizes methods of the interface named iface,
+// keyed by their arguments. Methods opt in with a directive giving how long
+// results are cached for, and optionally how many are kept:
+//
+//	//goku:cache ttl=30s size=100
+//	func (x *X) Get(ctx context.Context, id int) (User, error)
+//
+// Errors aren't cached. Arguments of builtin types and pointers are keys as
+// they are; anything else is printed into one along with its type, so 1 and
+// int64(1) passed as an any don't collide. Methods with arguments that can't
+// be a key, like funcs and channels, are rejected
+func (s StructContract) GenCacheDecorator(iface string, opts ...DecoratorOpt) ([]byte, error) {
+	d := s.newDecorator(iface, "Caching", opts)
+	d.use("time", decorrtPath)
+	d.declares("Purge")
+
+	err := d.addMethods(s, cacheReserved, func(m *decoratedMethod) error {
+		directive, ok := m.Directive("cache")
+		if !ok {
+			return nil
+		}
+
+		if len(m.Values()) == 0 {
+			return errors.New("//goku:cache needs the method to return something other than an error")
+		}
+
+		options := directive.Options()
+		ttl, err := time.ParseDuration(options["ttl"])
+		if err != nil || ttl <= 0 {
+			return fmt.Errorf("//goku:cache needs a positive ttl, e.g. ttl=30s, got %q", options["ttl"])
+		}
+
+		size := defaultCacheSize
+		if v, ok := options["size"]; ok {
+			if size, err = strconv.Atoi(v); err != nil || size < 1 {
+				return fmt.Errorf("//goku:cache size must be a positive integer, got %q", v)
+			}
+		}
+
+		keyType := lowerName(d.Name) + m.Name + "Key"
+		valueType := lowerName(d.Name) + m.Name + "Value"
+
+		var keyFields, keys []string
+		for _, v := range m.Args() {
+			t, stringify, err := s.cacheKeyType(v.Type)
+			if err != nil {
+				return fmt.Errorf("argument %s: %w", v.Name, err)
+			}
+
+			keyFields = append(keyFields, v.Name+" "+t)
+			if stringify {
+				keys = append(keys, fmt.Sprintf("%s: decorrt.Key(%s)", v.Name, v.Name))
+			} else {
+				keys = append(keys, v.Name+": "+v.Name)
+			}
+		}
+
+		var valueFields, values, hit []string
+		for _, v := range m.Values() {
+			valueFields = append(valueFields, v.String())
+			values = append(values, v.Name+": "+v.Name)
+			hit = append(hit, "entry."+v.Name)
+		}
+		if m.Err != "" {
+			hit = append(hit, "nil")
+		}
+
+		m.Vars = map[string]string{
+			"cache":       "cache" + m.Name,
+			"keyType":     keyType,
+			"valueType":   valueType,
+			"keyFields":   strings.Join(keyFields, "\n"),
+			"valueFields": strings.Join(valueFields, "\n"),
+			"key":         fmt.Sprintf("%s%s{%s}", keyType, d.TypeArgs, strings.Join(keys, ", ")),
+			"value":       fmt.Sprintf("%s%s{%s}", valueType, d.TypeArgs, strings.Join(values, ", ")),
+			"hit":         strings.Join(hit, ", "),
+			"ttl":         durationExpr(ttl),
+			"size":        strconv.Itoa(size),
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return d.render("cache.go.tmpl")
+}
+
+// cacheKeyType decides how an argument of type t is part of a cache key.
+// Types that are comparable by their syntax alone, like builtin types,
+// pointers and arrays of them, are used as they are. Everything else,
+// including named types that may hide a slice or map, is printed to a string
+// with decorrt.Key, which includes the type. Types that can't be told apart
+// by value at all are an error
+func (s StructContract) cacheKeyType(t string) (string, bool, error) {
+	switch {
+	case s.unkeyable(t):
+		return "", false, fmt.Errorf("%s can't be part of a cache key", t)
+	case comparableType(t):
+		return t, false, nil
+	default:
+		return "string", true, nil
+	}
+}
+
+// builtin types whose values can always be compared with ==
+var comparableBuiltins = map[string]bool{
+	"bool": true, "string": true, "byte": true, "rune": true, "uintptr": true,
+	"int": true, "int8": true, "int16": true, "int32": true, "int64": true,
+	"uint": true, "uint8": true, "uint16": true, "uint32": true, "uint64": true,
+	"float32": true, "float64": true, "complex64": true, "complex128": true,
+}
+
+// comparableType reports whether t can be proven comparable from its syntax
+func comparableType(t string) bool {
+	switch {
+	case strings.HasPrefix(t, "*"):
+		return true
+	case strings.HasPrefix(t, "["):
+		end := strings.IndexByte(t, ']')
+		return end > 1 && comparableType(t[end+1:])
+	default:
+		return comparableBuiltins[t]
+	}
+}
+
+// unkeyable reports whether values of type t can't be told apart by value,
+// because it is or holds a func or channel, so they can't identify a call.
+// Named types declared in the package are resolved to what they're made of;
+// pointers and interfaces aren't looked into
+func (s StructContract) unkeyable(t string) bool {
+	expr, err := parser.ParseExpr(strings.Replace(t, "...", "[]", 1))
+	return err != nil || s.holdsFuncOrChan(expr, map[string]bool{})
+}
+
+func (s StructContract) holdsFuncOrChan(expr ast.Expr, seen map[string]bool) bool {
+	holds := func(e ast.Expr) bool { return s.holdsFuncOrChan(e, seen) }
+
+	switch e := expr.(type) {
+	case *ast.FuncType, *ast.ChanType:
+		return true
+	case *ast.Ident:
+		underlying, ok := s.types[e.Name]
+		if !ok || seen[e.Name] {
+			return false
+		}
+		seen[e.Name] = true
+		return holds(underlying)
+	case *ast.ParenExpr:
+		return holds(e.X)
+	case *ast.ArrayType:
+		return holds(e.Elt)
+	case *ast.MapType:
+		return holds(e.Key) || holds(e.Value)
+	case *ast.StructType:
+		return slices.ContainsFunc(e.Fields.List, func(f *ast.Field) bool { return holds(f.Type) })
+	case *ast.IndexExpr:
+		return holds(e.X) || holds(e.Index)
+	case *ast.IndexListExpr:
+		return holds(e.X) || slices.ContainsFunc(e.Indices, holds)
+	default:
+		return false
+	}
+}
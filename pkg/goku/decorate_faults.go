@@ -0,0 +1,19 @@
+package goku
+
+// locals the faults template declares
+var faultsReserved = []string{"decorator", "err"}
+
+// Generate a decorator that injects errors, latency and panics into calls to
+// the interface named iface, as the rules of a decorrt.Faults pick, to test
+// how callers cope when it misbehaves. Errors are returned through a method's
+// error result, so methods without one only get latency and panics
+func (s StructContract) GenFaultsDecorator(iface string, opts ...DecoratorOpt) ([]byte, error) {
+	d := s.newDecorator(iface, "Faulty", opts)
+	d.use("context", decorrtPath)
+
+	if err := d.addMethods(s, faultsReserved, nil); err != nil {
+		return nil, err
+	}
+
+	return d.render("faults.go.tmpl")
+}
@@ -0,0 +1,39 @@
+package goku
+
+import "strings"
+
+// locals the hooks template declares
+var hooksReserved = []string{"decorator", "args", "results", "call", "hook", "start", "dur"}
+
+// Generate a decorator that calls hooks before and after every call to the
+// interface named iface, with the method's name, its arguments excluding a
+// leading context, and its results. Each method can also be intercepted with
+// a typed middleware that wraps the call
+func (s StructContract) GenHooksDecorator(iface string, opts ...DecoratorOpt) ([]byte, error) {
+	d := s.newDecorator(iface, "Hooked", opts)
+	d.use("time")
+
+	err := d.addMethods(s, hooksReserved, func(m *decoratedMethod) error {
+		var args, values []string
+		for _, v := range m.Args() {
+			args = append(args, v.Name)
+		}
+
+		for _, v := range m.Results {
+			values = append(values, v.Name)
+		}
+
+		sig := MethodInfo{Arguments: m.Params, Returns: m.Returns}
+		m.Vars = map[string]string{
+			"args":    "[]any{" + strings.Join(args, ", ") + "}",
+			"results": "[]any{" + strings.Join(values, ", ") + "}",
+			"func":    params(&sig) + results(&sig, nil),
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return d.render("hooks.go.tmpl")
+}
@@ -0,0 +1,22 @@
+package goku
+
+// locals the limiting template declares
+var limitReserved = []string{"decorator"}
+
+// Generate a decorator that limits calls to the interface named iface with a
+// token bucket, a cap on calls in flight, or both, either per method or
+// shared by all of them. Methods that take a context wait until they're
+// allowed or it's done. Ones that don't fail fast with decorrt.ErrLimited
+// through their error result, and methods that can't return an error always
+// wait
+func (s StructContract) GenLimitDecorator(iface string, opts ...DecoratorOpt) ([]byte, error) {
+	d := s.newDecorator(iface, "Limited", opts)
+	d.use("context", decorrtPath)
+	d.declares("Limiter")
+
+	if err := d.addMethods(s, limitReserved, nil); err != nil {
+		return nil, err
+	}
+
+	return d.render("limit.go.tmpl")
+}
@@ -0,0 +1,138 @@
+package goku
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// locals and packages the logging template declares or uses
+var logReserved = []string{"decorator", "start", "attrs", "level"}
+
+// Generate a decorator that logs every call to the interface named iface
+// through a *slog.Logger, with the method's name, duration, arguments and
+// results, and its error if the last result is one. Methods can mask
+// sensitive values with a directive:
+//
+//	//goku:redact
+//	func (x *X) Login(user, password string) error // masks everything
+//
+//	//goku:redact password
+//	func (x *X) Login(user, password string) error // masks password
+//
+// or by putting //goku:redact after a parameter on its own line. Methods can
+// also pick which arguments are logged, and whether results are:
+//
+//	//goku:log user results=false
+//	func (x *X) Login(user, password string) (Session, error) // logs user only
+func (s StructContract) GenLogDecorator(iface string, opts ...DecoratorOpt) ([]byte, error) {
+	d := s.newDecorator(iface, "Logging", opts)
+	d.use("context", "log/slog", "time")
+
+	err := d.addMethods(s, logReserved, func(m *decoratedMethod) error {
+		redact, all, err := redacted(m)
+		if err != nil {
+			return err
+		}
+
+		logged, logResults, err := loggedAttrs(m)
+		if err != nil {
+			return err
+		}
+
+		offset := len(m.Params) - len(m.Args())
+		for i, v := range m.Args() {
+			key := m.Arguments[i+offset].Name
+			if logged != nil && !slices.Contains(logged, key) {
+				continue
+			}
+			m.ArgAttrs = append(m.ArgAttrs, logAttr(key, v.Name, all || slices.Contains(redact, key)))
+		}
+
+		if !logResults {
+			return nil
+		}
+
+		for i, v := range m.Values() {
+			m.ResultAttrs = append(m.ResultAttrs, logAttr(fmt.Sprintf("r%d", i), v.Name, all))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return d.render("log.go.tmpl")
+}
+
+// loggedAttrs reads the //goku:log directive of m: the arguments to log, or
+// nil for all of them, and whether to log results
+func loggedAttrs(m *decoratedMethod) ([]string, bool, error) {
+	directive, ok := m.Directive("log")
+	if !ok {
+		return nil, true, nil
+	}
+
+	offset := len(m.Params) - len(m.Args())
+
+	logged, logResults := []string{}, true
+	for _, v := range directive.Fields() {
+		k, val, isOption := strings.Cut(v, "=")
+		switch {
+		case !isOption:
+			if !slices.ContainsFunc(m.Arguments[offset:], func(arg TypeInfo) bool { return arg.Name == k }) {
+				return nil, false, fmt.Errorf("//goku:log names %s, which isn't a parameter that can be logged", k)
+			}
+			logged = append(logged, k)
+		case k == "results":
+			var err error
+			if logResults, err = strconv.ParseBool(val); err != nil {
+				return nil, false, fmt.Errorf("//goku:log results must be true or false, got %q", val)
+			}
+		default:
+			return nil, false, fmt.Errorf("//goku:log doesn't know %s, only results", k)
+		}
+	}
+
+	if len(logged) == 0 {
+		logged = nil
+	}
+	return logged, logResults, nil
+}
+
+// redacted lists the parameters of m marked with //goku:redact, or reports
+// that the whole method is. Naming anything that isn't one of the method's
+// arguments is an error, so a typo can't leave a secret unmasked
+func redacted(m *decoratedMethod) ([]string, bool, error) {
+	offset := len(m.Params) - len(m.Args())
+
+	var names []string
+	for _, v := range m.Directives {
+		if v.Name != "redact" {
+			continue
+		}
+
+		fields := v.Fields()
+		if len(fields) == 0 {
+			return nil, true, nil
+		}
+
+		for _, name := range fields {
+			if !slices.ContainsFunc(m.Arguments[offset:], func(arg TypeInfo) bool { return arg.Name == name }) {
+				return nil, false, fmt.Errorf("//goku:redact names %s, which isn't a parameter", name)
+			}
+		}
+		names = append(names, fields...)
+	}
+
+	return names, false, nil
+}
+
+func logAttr(key, value string, redact bool) string {
+	if redact {
+		return fmt.Sprintf(`slog.String(%s, "[REDACTED]")`, strconv.Quote(key))
+	}
+	return fmt.Sprintf("slog.Any(%s, %s)", strconv.Quote(key), value)
+}
@@ -0,0 +1,20 @@
+package goku
+
+// locals the metrics template declares
+var metricsReserved = []string{"decorator", "start"}
+
+// Generate a decorator that records call counts, error counts and latencies
+// of every call to the interface named iface. Measurements go to a small
+// generated <iface>Metrics interface, so any backend can be plugged in
+// without the generated code depending on it. Method names are generated as
+// constants, <iface>Method<name>, to use as labels
+func (s StructContract) GenMetricsDecorator(iface string, opts ...DecoratorOpt) ([]byte, error) {
+	d := s.newDecorator(iface, "Instrumented", opts)
+	d.use("time")
+
+	if err := d.addMethods(s, metricsReserved, nil); err != nil {
+		return nil, err
+	}
+
+	return d.render("metrics.go.tmpl")
+}
@@ -0,0 +1,19 @@
+package goku
+
+// locals the recovering template declares
+var recoverReserved = []string{"decorator", "recovered"}
+
+// Generate a decorator that recovers panics from calls to the interface named
+// iface, as a *decorrt.PanicError holding the panic's value and stack. Methods
+// whose last result is an error return it; for any other method it's handed
+// to a handler
+func (s StructContract) GenRecoverDecorator(iface string, opts ...DecoratorOpt) ([]byte, error) {
+	d := s.newDecorator(iface, "Recovering", opts)
+	d.use(decorrtPath)
+
+	if err := d.addMethods(s, recoverReserved, nil); err != nil {
+		return nil, err
+	}
+
+	return d.render("recover.go.tmpl")
+}
@@ -0,0 +1,23 @@
+package goku
+
+// locals the retry template declares
+var retryReserved = []string{"decorator"}
+
+// Generate a decorator that retries calls to the interface named iface that
+// fail, following a decorrt.RetryPolicy. Only methods whose last result is
+// an error are retried, and waits between attempts are cut short when the
+// method's context is done. Methods that mustn't be retried, such as ones
+// that aren't idempotent, opt out with a directive:
+//
+//	//goku:noretry
+//	func (x *X) Charge(ctx context.Context, cents int) error
+func (s StructContract) GenRetryDecorator(iface string, opts ...DecoratorOpt) ([]byte, error) {
+	d := s.newDecorator(iface, "Retrying", opts)
+	d.use("context", decorrtPath)
+
+	if err := d.addMethods(s, retryReserved, nil); err != nil {
+		return nil, err
+	}
+
+	return d.render("retry.go.tmpl")
+}
@@ -0,0 +1,87 @@
+package goku
+
+import (
+	"fmt"
+	"strings"
+)
+
+// locals the singleflight template declares
+var singleflightReserved = []string{"decorator", "flight", "res", "err", "entry"}
+
+// Generate a decorator that collapses concurrent identical calls to the
+// interface named iface into one call, whose results every caller shares,
+// using golang.org/x/sync/singleflight. Calls are identical when they're to
+// the same method with arguments alike in type and value, as printed by
+// decorrt.Key. Methods opt in with a directive:
+//
+//	//goku:singleflight
+//	func (x *X) Get(ctx context.Context, id int) (User, error)
+//
+// The shared call runs with the first caller's context stripped of its
+// cancellation, so one caller giving up doesn't fail the rest. Methods with
+// arguments that can't identify a call, like funcs and channels, are rejected
+func (s StructContract) GenSingleflightDecorator(iface string, opts ...DecoratorOpt) ([]byte, error) {
+	d := s.newDecorator(iface, "Singleflight", opts)
+	d.use("context", "golang.org/x/sync/singleflight", decorrtPath)
+
+	err := d.addMethods(s, singleflightReserved, func(m *decoratedMethod) error {
+		if !m.HasDirective("singleflight") {
+			return nil
+		}
+
+		var args []string
+		for _, v := range m.Args() {
+			if s.unkeyable(v.Type) {
+				return fmt.Errorf("argument %s: %s can't identify a call", v.Name, v.Type)
+			}
+			args = append(args, v.Name)
+		}
+
+		key := fmt.Sprintf("%q", m.Name+"()")
+		if len(args) > 0 {
+			key = fmt.Sprintf("%q + decorrt.Key(%s) + %q", m.Name+"(", strings.Join(args, ", "), ")")
+		}
+
+		taken := map[string]struct{}{}
+		for _, v := range append(m.Params, m.Results...) {
+			taken[v.Name] = struct{}{}
+		}
+
+		var locals, fields, values, shared []string
+		for idx, v := range m.Values() {
+			local := freeName(fmt.Sprintf("v%d", idx), taken)
+			locals = append(locals, local)
+			fields = append(fields, v.String())
+			values = append(values, v.Name+": "+local)
+			shared = append(shared, "entry."+v.Name)
+		}
+
+		resultType := lowerName(d.Name) + m.Name + "Result"
+		result := "nil"
+		if len(values) > 0 {
+			result = resultType + d.TypeArgs + "{" + strings.Join(values, ", ") + "}"
+		}
+
+		callErr := "nil"
+		if m.Err != "" {
+			callErr = freeName("vErr", taken)
+			locals = append(locals, callErr)
+		}
+
+		m.Vars = map[string]string{
+			"key":        key,
+			"resultType": resultType,
+			"fields":     strings.Join(fields, "\n"),
+			"locals":     strings.Join(locals, ", "),
+			"result":     result,
+			"callErr":    callErr,
+			"shared":     strings.Join(shared, ", "),
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return d.render("singleflight.go.tmpl")
+}
@@ -0,0 +1,67 @@
+package goku
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// locals the synchronized template declares
+var syncReserved = []string{"decorator"}
+
+// Name prefixes of methods assumed to only read when locking with a
+// sync.RWMutex
+var readPrefixes = []string{"Get", "List"}
+
+// Generate a decorator that makes an implementation of the interface named
+// iface safe to share between goroutines, by holding a lock around every
+// call. With ReadWriteLock it's a sync.RWMutex, and methods that only read
+// share it: ones named Get... or List..., or that have a directive:
+//
+//	//goku:read
+//	func (x *X) Lookup(id int) User
+//
+// A method named like a read that writes can say so with //goku:write
+func (s StructContract) GenSyncDecorator(iface string, opts ...DecoratorOpt) ([]byte, error) {
+	d := s.newDecorator(iface, "Synchronized", opts)
+	d.use("sync")
+
+	err := d.addMethods(s, syncReserved, func(m *decoratedMethod) error {
+		lock, unlock := "Lock", "Unlock"
+		if d.ReadWrite && readOnly(m.MethodInfo) {
+			lock, unlock = "RLock", "RUnlock"
+		}
+
+		m.Vars = map[string]string{"lock": lock, "unlock": unlock}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return d.render("sync.go.tmpl")
+}
+
+// readOnly reports whether m is annotated or named as a method that only reads
+func readOnly(m MethodInfo) bool {
+	switch {
+	case m.HasDirective("write"):
+		return false
+	case m.HasDirective("read"):
+		return true
+	}
+
+	for _, v := range readPrefixes {
+		rest, ok := strings.CutPrefix(m.Name, v)
+		if !ok {
+			continue
+		}
+
+		// Get and GetUser are reads, Getaway isn't
+		if r, _ := utf8.DecodeRuneInString(rest); rest == "" || !unicode.IsLower(r) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,54 @@
+package goku
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// locals the timeout template declares
+var timeoutReserved = []string{"decorator", "timeout", "ok", "cancel"}
+
+// Generate a decorator that gives calls to the interface named iface a
+// deadline, by deriving a child context from the method's first argument.
+// Timeouts come from a map handed to the constructor, or default to the
+// method's directive:
+//
+//	//goku:timeout 2s
+//	func (x *X) Method(ctx context.Context) error
+//
+// Errors returned after the deadline passed always match
+// context.DeadlineExceeded. Methods that don't take a context aren't given a
+// deadline, and can't have the directive
+func (s StructContract) GenTimeoutDecorator(iface string, opts ...DecoratorOpt) ([]byte, error) {
+	d := s.newDecorator(iface, "Timeout", opts)
+	d.use("context", "maps", "time", decorrtPath)
+
+	err := d.addMethods(s, timeoutReserved, func(m *decoratedMethod) error {
+		directive, ok := m.Directive("timeout")
+		if !ok {
+			return nil
+		}
+
+		if m.Ctx == "" {
+			return errors.New("//goku:timeout needs the method's first argument to be a context.Context")
+		}
+
+		timeout, err := time.ParseDuration(directive.Args)
+		if err != nil {
+			return fmt.Errorf("//goku:timeout: %w", err)
+		}
+
+		if timeout <= 0 {
+			return fmt.Errorf("//goku:timeout must be positive, got %s", timeout)
+		}
+
+		m.Vars = map[string]string{"timeout": durationExpr(timeout)}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return d.render("timeout.go.tmpl")
+}
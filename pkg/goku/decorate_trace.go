@@ -0,0 +1,82 @@
+package goku
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// locals and packages the tracing template declares or uses
+var traceReserved = []string{"decorator", "span"}
+
+// attribute constructors for types OpenTelemetry supports natively. Anything
+// else is recorded as a string with fmt.Sprint
+var traceAttrFuncs = map[string]string{
+	"string":    "attribute.String",
+	"int":       "attribute.Int",
+	"int64":     "attribute.Int64",
+	"float64":   "attribute.Float64",
+	"bool":      "attribute.Bool",
+	"[]string":  "attribute.StringSlice",
+	"[]int":     "attribute.IntSlice",
+	"[]int64":   "attribute.Int64Slice",
+	"[]float64": "attribute.Float64Slice",
+	"[]bool":    "attribute.BoolSlice",
+}
+
+// Generate a decorator that starts an OpenTelemetry span around every call to
+// the interface named iface. Spans are children of the method's context when
+// its first argument is one, and of a base context given to the constructor
+// otherwise. A trailing error result is recorded on the span and sets its
+// status. Arguments are attached as attributes by naming them in a directive:
+//
+//	//goku:trace id name
+//	func (x *X) Method(ctx context.Context, id int, name string) error
+func (s StructContract) GenTraceDecorator(iface string, opts ...DecoratorOpt) ([]byte, error) {
+	d := s.newDecorator(iface, "Tracing", opts)
+	d.use(
+		"context",
+		"fmt",
+		"go.opentelemetry.io/otel/attribute",
+		"go.opentelemetry.io/otel/codes",
+		"go.opentelemetry.io/otel/trace",
+	)
+
+	err := d.addMethods(s, traceReserved, func(m *decoratedMethod) error {
+		directive, ok := m.Directive("trace")
+		if !ok {
+			return nil
+		}
+
+		for _, name := range directive.Fields() {
+			i := -1
+			for idx, v := range m.Arguments {
+				if v.Name == name {
+					i = idx
+				}
+			}
+
+			if i == -1 || i == 0 && m.Ctx != "" {
+				return fmt.Errorf("//goku:trace names %s, which isn't a parameter that can be an attribute", name)
+			}
+
+			m.ArgAttrs = append(m.ArgAttrs, traceAttr(name, m.Params[i]))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return d.render("trace.go.tmpl")
+}
+
+func traceAttr(key string, param TypeInfo) string {
+	t := strings.Replace(param.Type, "...", "[]", 1)
+	if fn, ok := traceAttrFuncs[t]; ok {
+		return fmt.Sprintf("%s(%s, %s)", fn, strconv.Quote(key), param.Name)
+	}
+
+	return fmt.Sprintf("attribute.String(%s, fmt.Sprint(%s))", strconv.Quote(key), param.Name)
+}
@@ -0,0 +1,64 @@
+package goku
+
+import "strings"
+
+// locals the record and replay templates declare
+var vcrReserved = []string{"decorator", "err"}
+
+// Generate a decorator that records every call to the interface named iface
+// onto a decorrt.Cassette, which can be saved as JSON and replayed by the
+// decorator GenReplayDecorator generates. Arguments and results are encoded
+// as JSON, except a leading context, and errors are kept as their messages
+func (s StructContract) GenRecordDecorator(iface string, opts ...DecoratorOpt) ([]byte, error) {
+	d := s.newDecorator(iface, "Recording", opts)
+	d.use(decorrtPath)
+
+	if err := d.addMethods(s, vcrReserved, vcrVars); err != nil {
+		return nil, err
+	}
+
+	return d.render("record.go.tmpl")
+}
+
+// Generate an implementation of the interface named iface that serves calls
+// from a decorrt.Cassette recorded by the decorator GenRecordDecorator
+// generates. Calls that weren't recorded fail with decorrt.ErrUnmatched, or
+// panic if the method can't return an error
+func (s StructContract) GenReplayDecorator(iface string, opts ...DecoratorOpt) ([]byte, error) {
+	d := s.newDecorator(iface, "Replaying", opts)
+	d.use(decorrtPath)
+
+	if err := d.addMethods(s, vcrReserved, vcrVars); err != nil {
+		return nil, err
+	}
+
+	return d.render("replay.go.tmpl")
+}
+
+// vcrVars lists what's recorded of each call
+func vcrVars(m *decoratedMethod) error {
+	var args, values, pointers []string
+	for _, v := range m.Args() {
+		args = append(args, v.Name)
+	}
+
+	for _, v := range m.Values() {
+		values = append(values, v.Name)
+		pointers = append(pointers, "&"+v.Name)
+	}
+
+	m.Vars = map[string]string{
+		"args":     anySlice(args),
+		"values":   anySlice(values),
+		"pointers": strings.Join(pointers, ", "),
+	}
+	return nil
+}
+
+// anySlice renders exprs as a []any literal, or nil if there aren't any
+func anySlice(exprs []string) string {
+	if len(exprs) == 0 {
+		return "nil"
+	}
+	return "[]any{" + strings.Join(exprs, ", ") + "}"
+}
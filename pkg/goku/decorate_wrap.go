@@ -0,0 +1,90 @@
+package goku
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// locals the wrapping template declares
+var wrapReserved = []string{"decorator"}
+
+// a single fmt verb, with its flags, width and precision. %w is left out: the
+// error is already wrapped
+var wrapVerb = regexp.MustCompile(`^%[-+# 0]*[0-9]*(\.[0-9]*)?[vTtbcdoOqxXUeEfFgGsp]$`)
+
+// Generate a decorator that wraps errors returned from the interface named
+// iface with the struct's and method's names and the call's arguments, using
+// %w so errors.Is and errors.As still see through it, e.g.
+//
+//	Store.Get(id=42): sql: no rows in result set
+//
+// Every argument but a leading context is included with %v, except ones
+// masked by //goku:redact. A directive picks which are included instead,
+// optionally with their own verb, and //goku:nowrap leaves a method's errors
+// alone:
+//
+//	//goku:wrap id name=%q
+//	func (x *X) Rename(ctx context.Context, id int, name string, force bool) error
+func (s StructContract) GenWrapDecorator(iface string, opts ...DecoratorOpt) ([]byte, error) {
+	d := s.newDecorator(iface, "Wrapping", opts)
+	d.use("fmt")
+
+	err := d.addMethods(s, wrapReserved, func(m *decoratedMethod) error {
+		if m.Err == "" || m.HasDirective("nowrap") {
+			return nil
+		}
+
+		offset := len(m.Params) - len(m.Args())
+		type arg struct{ key, value, verb string }
+
+		var args []arg
+		if directive, ok := m.Directive("wrap"); ok {
+			for _, field := range directive.Fields() {
+				name, verb, ok := strings.Cut(field, "=")
+				if !ok {
+					verb = "%v"
+				} else if !wrapVerb.MatchString(verb) {
+					return fmt.Errorf("//goku:wrap %s needs a verb like %%v or %%q, got %q", name, verb)
+				}
+
+				i := slices.IndexFunc(m.Arguments[offset:], func(v TypeInfo) bool { return v.Name == name })
+				if i == -1 {
+					return fmt.Errorf("//goku:wrap names %s, which isn't a parameter", name)
+				}
+				args = append(args, arg{name, m.Args()[i].Name, verb})
+			}
+		} else {
+			redact, all, err := redacted(m)
+			if err != nil {
+				return err
+			}
+			for i, v := range m.Arguments[offset:] {
+				if all || v.Name == "" || v.Name == "_" || slices.Contains(redact, v.Name) {
+					continue
+				}
+				args = append(args, arg{v.Name, m.Args()[i].Name, "%v"})
+			}
+		}
+
+		format := make([]string, len(args))
+		var values []string
+		for i, v := range args {
+			format[i] = v.key + "=" + v.verb
+			values = append(values, v.value)
+		}
+
+		m.Vars = map[string]string{
+			"format": strconv.Quote(fmt.Sprintf("%s.%s(%s): %%w", s.StructName, m.Name, strings.Join(format, ", "))),
+			"args":   strings.Join(append(values, m.Err), ", "),
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return d.render("wrap.go.tmpl")
+}
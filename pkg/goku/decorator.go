@@ -0,0 +1,340 @@
+package goku
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const decorrtPath = "github.com/AnthonyHewins/goku/pkg/goku/decorrt"
+
+type DecoratorOpt func(*decorator)
+
+// decorator holds everything a template needs to wrap an interface in a
+// struct that implements it by delegating to another implementation
+type decorator struct {
+	PkgName    string
+	Imports    []Import
+	Iface      string
+	Name       string
+	TypeParams string
+	TypeArgs   string
+	Methods    []decoratedMethod
+
+	// Guard methods with a sync.RWMutex rather than a sync.Mutex
+	ReadWrite bool
+
+	// imports the decorator may not end up using, by path
+	optional   map[string]struct{}
+	genPrivate bool
+	// methods the template declares besides the interface's
+	helpers []string
+}
+
+// decoratedMethod is a method as a decorator sees it: parameters that would
+// collide with anything the template declares are renamed, and results are
+// named so bodies can assign to them and return
+type decoratedMethod struct {
+	MethodInfo
+	Params  []TypeInfo
+	Results []TypeInfo
+
+	// Signature without the func keyword or name, with named results
+	Signature string
+	// Arguments to pass through to the wrapped method
+	Call string
+	// Name of the context.Context parameter, if it's the first one
+	Ctx string
+	// Name of the error result, if it's the last one
+	Err string
+
+	// Attribute expressions for the arguments and results, excluding the
+	// context and error, as some templates want them
+	ArgAttrs    []string
+	ResultAttrs []string
+
+	// Anything else a template needs per method, keyed however it likes
+	Vars map[string]string
+}
+
+// Name the generated decorator; the default depends on the kind of decorator
+func DecoratorName(name string) DecoratorOpt { return func(d *decorator) { d.Name = name } }
+
+// Override the package name of the generated decorator
+func DecoratorPkg(s string) DecoratorOpt { return func(d *decorator) { d.PkgName = s } }
+
+// Decorate private methods too. The interface must've been generated with
+// private methods included
+func DecoratePrivate() DecoratorOpt { return func(d *decorator) { d.genPrivate = true } }
+
+// Synchronize with a sync.RWMutex, letting methods that only read run
+// concurrently. Other kinds of decorators ignore it
+func ReadWriteLock() DecoratorOpt { return func(d *decorator) { d.ReadWrite = true } }
+
+func (s StructContract) newDecorator(iface, prefix string, opts []DecoratorOpt) *decorator {
+	d := &decorator{
+		PkgName:  s.PkgName,
+		Imports:  s.Imports,
+		Iface:    iface,
+		Name:     prefix + iface,
+		optional: map[string]struct{}{},
+	}
+
+	for _, v := range opts {
+		v(d)
+	}
+
+	if len(s.StructTypeParams) > 0 {
+		d.TypeParams = typeParamList(s.StructTypeParams)
+		d.TypeArgs = typeArgList(s.StructTypeParams)
+	}
+
+	return d
+}
+
+// use adds imports the template might need. Any of them the generated code
+// doesn't reference are dropped when it's rendered
+func (d *decorator) use(paths ...string) {
+	for _, v := range paths {
+		d.optional[v] = struct{}{}
+		d.Imports = withImports(d.Imports, Import{Path: v})
+	}
+}
+
+// declares records methods the template adds to the decorator besides the
+// interface's, so an interface that already has one is an error rather than
+// generated code that doesn't compile
+func (d *decorator) declares(methods ...string) {
+	d.helpers = append(d.helpers, methods...)
+}
+
+// addMethods adds every method of s the decorator should implement. Parameters
+// named after anything in reserved, or any package the decorator imports, are
+// renamed. prep can fill in template specific details, or reject the method
+func (d *decorator) addMethods(s StructContract, reserved []string, prep func(*decoratedMethod) error) error {
+	taken := map[string]struct{}{}
+	for _, v := range reserved {
+		taken[v] = struct{}{}
+	}
+
+	for _, v := range d.Imports {
+		taken[importName(v)] = struct{}{}
+	}
+
+	for _, v := range s.Methods {
+		if len(v.Name) == 0 || (unicode.IsLower(rune(v.Name[0])) && !d.genPrivate) {
+			continue
+		}
+
+		m := newDecoratedMethod(v, taken)
+		if prep != nil {
+			if err := prep(&m); err != nil {
+				return fmt.Errorf("method %s: %w", v.Name, err)
+			}
+		}
+
+		d.Methods = append(d.Methods, m)
+	}
+
+	return nil
+}
+
+func newDecoratedMethod(v MethodInfo, reserved map[string]struct{}) decoratedMethod {
+	m := decoratedMethod{MethodInfo: v}
+
+	taken := make(map[string]struct{}, len(reserved)+len(v.Arguments))
+	for k := range reserved {
+		taken[k] = struct{}{}
+	}
+
+	renamed := v
+	renamed.Arguments = make([]TypeInfo, len(v.Arguments))
+	for idx, arg := range v.Arguments {
+		arg.Name = freeName(arg.Name, taken)
+		taken[arg.Name] = struct{}{}
+		renamed.Arguments[idx] = arg
+	}
+	m.Params = renamed.Arguments
+
+	names := make([]string, len(v.Returns))
+	for idx, t := range v.Returns {
+		names[idx] = freeName(fmt.Sprintf("r%d", idx), taken)
+		taken[names[idx]] = struct{}{}
+		m.Results = append(m.Results, TypeInfo{Name: names[idx], Type: t})
+	}
+
+	m.Signature = params(&renamed) + results(&renamed, names)
+	m.Call = callArgs(m.Params)
+	if renamed.ContextArg() != "" {
+		m.Ctx = m.Params[0].Name
+	}
+	if v.ReturnsError() {
+		m.Err = names[len(names)-1]
+	}
+
+	return m
+}
+
+// Params excluding the context
+func (m *decoratedMethod) Args() []TypeInfo {
+	if m.Ctx != "" {
+		return m.Params[1:]
+	}
+	return m.Params
+}
+
+// Results excluding the error
+func (m *decoratedMethod) Values() []TypeInfo {
+	if m.Err != "" {
+		return m.Results[:len(m.Results)-1]
+	}
+	return m.Results
+}
+
+// Every result name, comma separated, for assigning all of them at once
+func (m *decoratedMethod) Assign() string {
+	names := make([]string, len(m.Results))
+	for i, v := range m.Results {
+		names[i] = v.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+// The context to hand to APIs that want one: the method's own if it takes
+// one, otherwise context.Background()
+func (m *decoratedMethod) Context() string {
+	if m.Ctx != "" {
+		return m.Ctx
+	}
+	return "context.Background()"
+}
+
+// durationExpr renders d as a Go expression, e.g. 2 * time.Second
+func durationExpr(d time.Duration) string {
+	units := []struct {
+		d    time.Duration
+		name string
+	}{
+		{time.Hour, "time.Hour"},
+		{time.Minute, "time.Minute"},
+		{time.Second, "time.Second"},
+		{time.Millisecond, "time.Millisecond"},
+		{time.Microsecond, "time.Microsecond"},
+	}
+
+	for _, u := range units {
+		if d%u.d == 0 {
+			if d == u.d {
+				return u.name
+			}
+			return fmt.Sprintf("%d * %s", d/u.d, u.name)
+		}
+	}
+
+	return fmt.Sprintf("time.Duration(%d)", int64(d))
+}
+
+// callArgs renders params as arguments to a call, spreading a variadic
+func callArgs(params []TypeInfo) string {
+	args := make([]string, len(params))
+	for i, v := range params {
+		args[i] = v.Name
+		if strings.HasPrefix(v.Type, "...") {
+			args[i] += "..."
+		}
+	}
+	return strings.Join(args, ", ")
+}
+
+func (d *decorator) render(tmpl string) ([]byte, error) {
+	for _, v := range d.Methods {
+		if slices.Contains(d.helpers, v.Name) {
+			return nil, fmt.Errorf("method %s: %s declares a %s method of its own, so it can't implement one too", v.Name, d.Name, v.Name)
+		}
+	}
+
+	var b bytes.Buffer
+	if err := tmpls.ExecuteTemplate(&b, tmpl, d); err != nil {
+		return nil, err
+	}
+
+	src, err := d.pruneImports(b.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	return format.Source(src)
+}
+
+// pruneImports drops the optional imports the rendered source never uses
+func (d *decorator) pruneImports(src []byte) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", src, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("generated invalid source: %w", err)
+	}
+
+	used := map[string]struct{}{}
+	ast.Inspect(file, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if x, ok := sel.X.(*ast.Ident); ok {
+				used[x.Name] = struct{}{}
+			}
+		}
+		return true
+	})
+
+	decls := file.Decls[:0]
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			decls = append(decls, decl)
+			continue
+		}
+
+		specs := gen.Specs[:0]
+		for _, spec := range gen.Specs {
+			imp := spec.(*ast.ImportSpec)
+			path, _ := strconv.Unquote(imp.Path.Value)
+
+			i := Import{Path: path}
+			if imp.Name != nil {
+				i.Alias = imp.Name.Name
+			}
+
+			_, optional := d.optional[path]
+			if _, ok := used[importName(i)]; ok || !optional {
+				specs = append(specs, spec)
+			}
+		}
+		if gen.Specs = specs; len(specs) > 0 {
+			decls = append(decls, gen)
+		}
+	}
+	file.Decls = decls
+
+	var b bytes.Buffer
+	if err := format.Node(&b, fset, file); err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
+// importName is the name an import is referred to by in source
+func importName(i Import) string {
+	if i.Alias != "" {
+		return i.Alias
+	}
+
+	parts := strings.Split(i.Path, "/")
+	return parts[len(parts)-1]
+}
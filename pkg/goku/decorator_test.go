@@ -0,0 +1,394 @@
+package goku
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenDecorators(mainTest *testing.T) {
+	var src []string
+	for _, v := range []string{"arg.go", "client.go"} {
+		b, err := files.ReadFile("testdata/decorate/" + v)
+		if err != nil {
+			mainTest.Fatalf("test file unreadable %s", err)
+		}
+		src = append(src, string(b))
+	}
+
+	contracts := map[string]*StructContract{}
+	for _, target := range []string{"Store", "Client"} {
+		gen := NewStructInfoGen(target)
+		if err := gen.AddSrc(src...); err != nil {
+			mainTest.Fatalf("should not err on generating source %s", err)
+		}
+
+		s, err := gen.StructInfo()
+		if err != nil {
+			mainTest.Fatalf("should not err on struct info %s", err)
+		}
+		contracts[target] = s
+	}
+
+	testCases := []struct {
+		name   string
+		target string
+		gen    func(StructContract, string, ...DecoratorOpt) ([]byte, error)
+		opts   []DecoratorOpt
+	}{
+		{name: "log", target: "Store", gen: StructContract.GenLogDecorator},
+		{name: "trace", target: "Store", gen: StructContract.GenTraceDecorator},
+		{name: "metrics", target: "Store", gen: StructContract.GenMetricsDecorator},
+		{name: "retry", target: "Store", gen: StructContract.GenRetryDecorator},
+		{name: "breaker", target: "Client", gen: StructContract.GenBreakerDecorator},
+		{name: "timeout", target: "Store", gen: StructContract.GenTimeoutDecorator},
+		{name: "sync", target: "Store", gen: StructContract.GenSyncDecorator},
+		{name: "sync-rw", target: "Store", gen: StructContract.GenSyncDecorator, opts: []DecoratorOpt{ReadWriteLock()}},
+		{name: "cache", target: "Store", gen: StructContract.GenCacheDecorator},
+		{name: "limit", target: "Store", gen: StructContract.GenLimitDecorator},
+		{name: "record", target: "Store", gen: StructContract.GenRecordDecorator},
+		{name: "replay", target: "Store", gen: StructContract.GenReplayDecorator},
+		{name: "recover", target: "Store", gen: StructContract.GenRecoverDecorator},
+		{name: "hooks", target: "Store", gen: StructContract.GenHooksDecorator},
+		{name: "faults", target: "Store", gen: StructContract.GenFaultsDecorator},
+		{name: "singleflight", target: "Store", gen: StructContract.GenSingleflightDecorator},
+		{name: "batch", target: "Client", gen: StructContract.GenBatchDecorator},
+		{name: "wrap", target: "Store", gen: StructContract.GenWrapDecorator},
+		{name: "authz", target: "Store", gen: StructContract.GenAuthzDecorator},
+		{name: "tee", target: "Store", gen: StructContract.GenTeeComposite},
+		{name: "fallback", target: "Store", gen: StructContract.GenFallbackComposite},
+		{name: "shadow", target: "Store", gen: StructContract.GenShadowComposite},
+	}
+
+	for _, tc := range testCases {
+		mainTest.Run(tc.name, func(tt *testing.T) {
+			want, err := files.ReadFile("testdata/decorate/" + tc.name + "-expected.txt")
+			if err != nil {
+				tt.Fatalf("test file unreadable %s", err)
+			}
+
+			b, err := tc.gen(*contracts[tc.target], tc.target+"Interface", tc.opts...)
+			if err != nil {
+				tt.Fatalf("should not err generating decorator %s", err)
+			}
+
+			got := strings.Split(strings.TrimSpace(string(b)), "\n")
+			wanted := strings.Split(strings.TrimSpace(string(want)), "\n")
+
+			if x, y := len(got), len(wanted); x != y {
+				tt.Fatalf("failed due to line count %d != %d, want\n%s\ngot\n%s", x, y, want, b)
+			}
+
+			for i, v := range wanted {
+				if strings.TrimSpace(v) != strings.TrimSpace(got[i]) {
+					tt.Errorf("failed at line %d:\n-%s\n+%s", i+1, v, got[i])
+				}
+			}
+		})
+	}
+}
+
+func TestDecoratorRejects(mainTest *testing.T) {
+	testCases := []struct {
+		name     string
+		src      string
+		gen      func(StructContract, string, ...DecoratorOpt) ([]byte, error)
+		contains string
+	}{
+		{
+			name:     "trace ctx",
+			src:      "//goku:trace ctx\nfunc (x *X) Get(ctx context.Context, id int) error { return nil }",
+			gen:      StructContract.GenTraceDecorator,
+			contains: "isn't a parameter",
+		},
+		{
+			name:     "redact typo",
+			src:      "//goku:redact pasword\nfunc (x *X) Login(ctx context.Context, user, password string) error { return nil }",
+			gen:      StructContract.GenLogDecorator,
+			contains: "isn't a parameter",
+		},
+		{
+			name:     "log unknown parameter",
+			src:      "//goku:log ctx\nfunc (x *X) Get(ctx context.Context, id int) error { return nil }",
+			gen:      StructContract.GenLogDecorator,
+			contains: "isn't a parameter that can be logged",
+		},
+		{
+			name:     "log bad results",
+			src:      "//goku:log results=maybe\nfunc (x *X) Get(id int) error { return nil }",
+			gen:      StructContract.GenLogDecorator,
+			contains: "true or false",
+		},
+		{
+			name:     "singleflight named func",
+			src:      "type Pred func(int) bool\n\n//goku:singleflight\nfunc (x *X) Find(ctx context.Context, p Pred) (int, error) { return 0, nil }",
+			gen:      StructContract.GenSingleflightDecorator,
+			contains: "can't identify a call",
+		},
+		{
+			name:     "cache send chan",
+			src:      "//goku:cache ttl=1s\nfunc (x *X) Get(ch chan<- int) int { return 0 }",
+			gen:      StructContract.GenCacheDecorator,
+			contains: "can't be part of a cache key",
+		},
+		{
+			name:     "cache Purge",
+			src:      "func (x *X) Purge() {}",
+			gen:      StructContract.GenCacheDecorator,
+			contains: "declares a Purge method of its own",
+		},
+		{
+			name:     "shadow Wait",
+			src:      "func (x *X) Wait() {}",
+			gen:      StructContract.GenShadowComposite,
+			contains: "declares a Wait method of its own",
+		},
+		{
+			name:     "breaker Breaker",
+			src:      "func (x *X) Breaker(method string) error { return nil }",
+			gen:      StructContract.GenBreakerDecorator,
+			contains: "declares a Breaker method of its own",
+		},
+		{
+			name:     "limit Limiter",
+			src:      "func (x *X) Limiter(method string) error { return nil }",
+			gen:      StructContract.GenLimitDecorator,
+			contains: "declares a Limiter method of its own",
+		},
+		{
+			name:     "authz Report",
+			src:      "func (x *X) Report(ctx context.Context) error { return nil }",
+			gen:      StructContract.GenAuthzDecorator,
+			contains: "declares a Report method of its own",
+		},
+		{
+			name:     "breaker without error",
+			src:      "func (x *X) Count() int { return 0 }",
+			gen:      StructContract.GenBreakerDecorator,
+			contains: "method Count",
+		},
+		{
+			name:     "timeout without ctx",
+			src:      "//goku:timeout 1s\nfunc (x *X) Get(id int) error { return nil }",
+			gen:      StructContract.GenTimeoutDecorator,
+			contains: "context.Context",
+		},
+		{
+			name:     "bad timeout",
+			src:      "//goku:timeout soon\nfunc (x *X) Get(ctx context.Context) error { return nil }",
+			gen:      StructContract.GenTimeoutDecorator,
+			contains: "invalid duration",
+		},
+		{
+			name:     "cache func arg",
+			src:      "//goku:cache ttl=1s\nfunc (x *X) Get(f func() int) int { return 0 }",
+			gen:      StructContract.GenCacheDecorator,
+			contains: "argument f",
+		},
+		{
+			name:     "cache without ttl",
+			src:      "//goku:cache\nfunc (x *X) Get(id int) int { return 0 }",
+			gen:      StructContract.GenCacheDecorator,
+			contains: "ttl",
+		},
+		{
+			name:     "cache nothing",
+			src:      "//goku:cache ttl=1s\nfunc (x *X) Save(id int) error { return nil }",
+			gen:      StructContract.GenCacheDecorator,
+			contains: "return something",
+		},
+		{
+			name:     "singleflight chan arg",
+			src:      "//goku:singleflight\nfunc (x *X) Get(c chan int) int { return 0 }",
+			gen:      StructContract.GenSingleflightDecorator,
+			contains: "argument c",
+		},
+		{
+			name:     "batch missing pair",
+			src:      "//goku:batch GetMany\nfunc (x *X) Get(ctx context.Context, id int) (int, error) { return 0, nil }",
+			gen:      StructContract.GenBatchDecorator,
+			contains: "no such method",
+		},
+		{
+			name:     "batch mismatched pair",
+			src:      "//goku:batch GetMany\nfunc (x *X) Get(ctx context.Context, id int) (int, error) { return 0, nil }\n\nfunc (x *X) GetMany(ctx context.Context, ids []string) ([]int, error) { return nil, nil }",
+			gen:      StructContract.GenBatchDecorator,
+			contains: "takes []string",
+		},
+		{
+			name:     "batch without ctx",
+			src:      "//goku:batch GetMany\nfunc (x *X) Get(id int) (int, error) { return 0, nil }\n\nfunc (x *X) GetMany(ids []int) ([]int, error) { return nil, nil }",
+			gen:      StructContract.GenBatchDecorator,
+			contains: "look like Get",
+		},
+		{
+			name:     "wrap unknown arg",
+			src:      "//goku:wrap name\nfunc (x *X) Get(ctx context.Context, id int) error { return nil }",
+			gen:      StructContract.GenWrapDecorator,
+			contains: "isn't a parameter",
+		},
+		{
+			name:     "wrap bad verb",
+			src:      "//goku:wrap id=%w\nfunc (x *X) Get(ctx context.Context, id int) error { return nil }",
+			gen:      StructContract.GenWrapDecorator,
+			contains: "needs a verb",
+		},
+		{
+			name:     "wrap bare verb",
+			src:      "//goku:wrap id=%\nfunc (x *X) Get(ctx context.Context, id int) error { return nil }",
+			gen:      StructContract.GenWrapDecorator,
+			contains: "needs a verb",
+		},
+		{
+			name:     "wrap trailing percent",
+			src:      "//goku:wrap id=%d%\nfunc (x *X) Get(ctx context.Context, id int) error { return nil }",
+			gen:      StructContract.GenWrapDecorator,
+			contains: "needs a verb",
+		},
+		{
+			name:     "wrap unknown verb",
+			src:      "//goku:wrap id=%y\nfunc (x *X) Get(ctx context.Context, id int) error { return nil }",
+			gen:      StructContract.GenWrapDecorator,
+			contains: "needs a verb",
+		},
+		{
+			name:     "wrap text after verb",
+			src:      "//goku:wrap id=%dms\nfunc (x *X) Get(ctx context.Context, id int) error { return nil }",
+			gen:      StructContract.GenWrapDecorator,
+			contains: "needs a verb",
+		},
+		{
+			name:     "authz without error",
+			src:      "//goku:authz role=admin\nfunc (x *X) Count(ctx context.Context) int { return 0 }",
+			gen:      StructContract.GenAuthzDecorator,
+			contains: "return an error",
+		},
+		{
+			name:     "authz without roles",
+			src:      "//goku:authz admin\nfunc (x *X) Delete(ctx context.Context) error { return nil }",
+			gen:      StructContract.GenAuthzDecorator,
+			contains: "only role",
+		},
+		{
+			name:     "authz unannotated without error",
+			src:      "func (x *X) Count(ctx context.Context) int { return 0 }",
+			gen:      StructContract.GenAuthzDecorator,
+			contains: "mark it //goku:noauthz",
+		},
+		{
+			name:     "authz and noauthz",
+			src:      "//goku:authz role=admin\n//goku:noauthz\nfunc (x *X) Delete(ctx context.Context) error { return nil }",
+			gen:      StructContract.GenAuthzDecorator,
+			contains: "contradict",
+		},
+	}
+
+	for _, tc := range testCases {
+		mainTest.Run(tc.name, func(tt *testing.T) {
+			gen := NewStructInfoGen("X")
+			err := gen.AddSrc("package x\n\nimport \"context\"\n\nvar _ context.Context\n\ntype X struct{}\n\n" + tc.src)
+			if err != nil {
+				tt.Fatalf("should not err on generating source %s", err)
+			}
+
+			s, err := gen.StructInfo()
+			if err != nil {
+				tt.Fatalf("should not err on struct info %s", err)
+			}
+
+			if _, err = tc.gen(*s, "XInterface"); err == nil || !strings.Contains(err.Error(), tc.contains) {
+				tt.Errorf("wanted an error containing %q but got %v", tc.contains, err)
+			}
+		})
+	}
+}
+
+func TestReadOnly(mainTest *testing.T) {
+	testCases := []struct {
+		name     string
+		method   MethodInfo
+		expected bool
+	}{
+		{name: "get", method: MethodInfo{Name: "Get"}, expected: true},
+		{name: "get prefix", method: MethodInfo{Name: "GetUser"}, expected: true},
+		{name: "list prefix", method: MethodInfo{Name: "ListUsers"}, expected: true},
+		{name: "not a word boundary", method: MethodInfo{Name: "Getaway"}},
+		{name: "write", method: MethodInfo{Name: "Save"}},
+		{name: "annotated read", method: MethodInfo{Name: "Lookup", Directives: []Directive{{Name: "read"}}}, expected: true},
+		{name: "annotated write", method: MethodInfo{Name: "GetOrCreate", Directives: []Directive{{Name: "write"}}}},
+	}
+
+	for _, tc := range testCases {
+		mainTest.Run(tc.name, func(tt *testing.T) {
+			if got := readOnly(tc.method); got != tc.expected {
+				tt.Errorf("wanted %v but got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestCacheKeyType(mainTest *testing.T) {
+	info := NewStructInfoGen("X")
+	err := info.AddSrc(`package x
+type X struct{}
+type IDs []int
+type Pred func(int) bool
+type Preds []Pred
+type Opts struct{ Filter Pred }
+type Ptr *Opts
+`)
+	if err != nil {
+		mainTest.Fatal(err)
+	}
+
+	s, err := info.StructInfo()
+	if err != nil {
+		mainTest.Fatal(err)
+	}
+
+	testCases := []struct {
+		name      string
+		t         string
+		stringify bool
+		err       bool
+	}{
+		{name: "builtin", t: "int"},
+		{name: "pointer", t: "*User"},
+		{name: "array", t: "[4]byte"},
+		{name: "array of slices", t: "[4][]byte", stringify: true},
+		{name: "any", t: "any", stringify: true},
+		{name: "slice", t: "[]int", stringify: true},
+		{name: "variadic", t: "...int", stringify: true},
+		{name: "named", t: "IDs", stringify: true},
+		{name: "imported", t: "uuid.UUID", stringify: true},
+		{name: "pointer to a func holder", t: "Ptr", stringify: true},
+		{name: "func", t: "func() int", err: true},
+		{name: "chan", t: "chan int", err: true},
+		{name: "send chan", t: "chan<- int", err: true},
+		{name: "receive chan", t: "<-chan int", err: true},
+		{name: "named func", t: "Pred", err: true},
+		{name: "slice of named funcs", t: "[]Preds", err: true},
+		{name: "struct holding a func", t: "Opts", err: true},
+		{name: "map of funcs", t: "map[string]func()", err: true},
+	}
+
+	for _, tc := range testCases {
+		mainTest.Run(tc.name, func(tt *testing.T) {
+			got, stringify, err := s.cacheKeyType(tc.t)
+			if (err != nil) != tc.err {
+				tt.Fatalf("wanted an error %v, got %v", tc.err, err)
+			}
+
+			if tc.err {
+				return
+			}
+
+			if stringify != tc.stringify {
+				tt.Errorf("wanted stringify %v but got %v", tc.stringify, stringify)
+			}
+
+			if want := map[bool]string{true: "string", false: tc.t}[tc.stringify]; got != want {
+				tt.Errorf("wanted key type %s but got %s", want, got)
+			}
+		})
+	}
+}
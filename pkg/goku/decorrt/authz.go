@@ -0,0 +1,117 @@
+package decorrt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"text/tabwriter"
+)
+
+// ErrForbidden matches every *ForbiddenError with errors.Is
+var ErrForbidden = errors.New("forbidden")
+
+// ForbiddenError is returned instead of making a call the caller isn't
+// allowed to make
+type ForbiddenError struct {
+	Method string
+	// Roles that would've been allowed. Empty if the call was denied by
+	// default
+	Roles []string
+	// Whether a principal was found for the call at all
+	Authenticated bool
+}
+
+func (e *ForbiddenError) Error() string {
+	switch {
+	case len(e.Roles) == 0:
+		return fmt.Sprintf("%s: forbidden by default", e.Method)
+	case !e.Authenticated:
+		return fmt.Sprintf("%s: forbidden: no principal", e.Method)
+	default:
+		return fmt.Sprintf("%s: forbidden: needs role %s", e.Method, strings.Join(e.Roles, " or "))
+	}
+}
+
+func (e *ForbiddenError) Is(target error) bool { return target == ErrForbidden }
+
+// Principal is whoever is making a call
+type Principal interface {
+	HasRole(role string) bool
+}
+
+// Roles is a Principal with a fixed set of roles
+type Roles []string
+
+func (r Roles) HasRole(role string) bool { return slices.Contains(r, role) }
+
+// Authorizer decides whether calls are allowed
+type Authorizer struct {
+	// Finds who's making a call from its context. ok is false when there's
+	// nobody, and the call is only allowed if its method is by default
+	Principal func(ctx context.Context) (p Principal, ok bool)
+	// Whether methods without any roles are allowed. Otherwise nobody can
+	// call them
+	DefaultAllow bool
+}
+
+// Check whether a call to method is allowed. Principals need one of roles;
+// when there aren't any, the default decides. Denied calls get a
+// *ForbiddenError
+func (a Authorizer) Check(ctx context.Context, method string, roles ...string) error {
+	if len(roles) == 0 {
+		if a.DefaultAllow {
+			return nil
+		}
+		return &ForbiddenError{Method: method}
+	}
+
+	var (
+		p  Principal
+		ok bool
+	)
+	if a.Principal != nil {
+		p, ok = a.Principal(ctx)
+	}
+
+	if ok && p != nil && slices.ContainsFunc(roles, p.HasRole) {
+		return nil
+	}
+
+	return &ForbiddenError{Method: method, Roles: roles, Authenticated: ok && p != nil}
+}
+
+// MethodPolicy is the roles allowed to call a method. Methods without any
+// follow the Authorizer's default, unless they're exempt
+type MethodPolicy struct {
+	Method string
+	Roles  []string
+	// Whether calls aren't checked at all, because the method is marked
+	// //goku:noauthz
+	Exempt bool
+}
+
+// PolicyReport renders policies as a table of each method and who can call
+// it, given whether methods without roles are allowed by default
+func PolicyReport(policies []MethodPolicy, defaultAllow bool) string {
+	var sb strings.Builder
+	w := tabwriter.NewWriter(&sb, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "METHOD\tPOLICY")
+
+	for _, v := range policies {
+		switch {
+		case v.Exempt:
+			fmt.Fprintf(w, "%s\texempt (noauthz)\n", v.Method)
+		case len(v.Roles) > 0:
+			fmt.Fprintf(w, "%s\trole=%s\n", v.Method, strings.Join(v.Roles, ","))
+		case defaultAllow:
+			fmt.Fprintf(w, "%s\tallow (default)\n", v.Method)
+		default:
+			fmt.Fprintf(w, "%s\tdeny (default)\n", v.Method)
+		}
+	}
+
+	w.Flush()
+	return sb.String()
+}
@@ -0,0 +1,62 @@
+package decorrt
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type principalKey struct{}
+
+func TestAuthorizerCheck(mainTest *testing.T) {
+	a := Authorizer{Principal: func(ctx context.Context) (Principal, bool) {
+		p, ok := ctx.Value(principalKey{}).(Principal)
+		return p, ok
+	}}
+
+	admin := context.WithValue(context.Background(), principalKey{}, Roles{"admin"})
+	viewer := context.WithValue(context.Background(), principalKey{}, Roles{"viewer"})
+
+	testCases := []struct {
+		name         string
+		ctx          context.Context
+		roles        []string
+		defaultAllow bool
+		expectedErr  string
+	}{
+		{name: "has role", ctx: admin, roles: []string{"admin"}},
+		{name: "has one of the roles", ctx: viewer, roles: []string{"admin", "viewer"}},
+		{name: "missing role", ctx: viewer, roles: []string{"admin"}, expectedErr: "Get: forbidden: needs role admin"},
+		{name: "no principal", ctx: context.Background(), roles: []string{"admin"}, expectedErr: "Get: forbidden: no principal"},
+		{name: "default deny", ctx: admin, expectedErr: "Get: forbidden by default"},
+		{name: "default allow", ctx: context.Background(), defaultAllow: true},
+	}
+
+	for _, tc := range testCases {
+		mainTest.Run(tc.name, func(tt *testing.T) {
+			a.DefaultAllow = tc.defaultAllow
+			err := a.Check(tc.ctx, "Get", tc.roles...)
+
+			if tc.expectedErr == "" {
+				if err != nil {
+					tt.Errorf("should be allowed, got %v", err)
+				}
+				return
+			}
+
+			var forbidden *ForbiddenError
+			if !errors.Is(err, ErrForbidden) || !errors.As(err, &forbidden) || err.Error() != tc.expectedErr {
+				tt.Errorf("wanted a *ForbiddenError %q but got %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestPolicyReport(t *testing.T) {
+	policies := []MethodPolicy{{Method: "Get", Roles: []string{"admin", "viewer"}}, {Method: "Count"}, {Method: "Touch", Exempt: true}}
+
+	want := "METHOD  POLICY\nGet     role=admin,viewer\nCount   deny (default)\nTouch   exempt (noauthz)\n"
+	if got := PolicyReport(policies, false); got != want {
+		t.Errorf("wanted\n%s\ngot\n%s", want, got)
+	}
+}
@@ -0,0 +1,105 @@
+package decorrt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrBatchSize is returned to every caller in a batch when the batch call
+// doesn't return one value per key
+var ErrBatchSize = errors.New("batch returned the wrong number of values")
+
+// BatchConfig tunes how a Batcher groups calls
+type BatchConfig struct {
+	// How long a batch waits for more keys after its first one
+	Wait time.Duration
+	// Keys a batch can hold before it's sent without waiting any longer.
+	// Zero leaves it unlimited
+	MaxSize int
+}
+
+// Batcher groups concurrent loads of single keys into calls that fetch many
+// at once, e.g. to turn N queries for N rows into one. It's safe for
+// concurrent use
+type Batcher[K, V any] struct {
+	cfg   BatchConfig
+	fetch func(context.Context, []K) ([]V, error)
+
+	mu      sync.Mutex
+	pending *batch[K, V]
+}
+
+type batch[K, V any] struct {
+	ctx   context.Context
+	keys  []K
+	timer *time.Timer
+
+	done   chan struct{}
+	values []V
+	err    error
+}
+
+// NewBatcher loads keys in batches with fetch, which must return a value for
+// each key it's given, in the same order
+func NewBatcher[K, V any](cfg BatchConfig, fetch func(context.Context, []K) ([]V, error)) *Batcher[K, V] {
+	return &Batcher[K, V]{cfg: cfg, fetch: fetch}
+}
+
+// Load key as part of the next batch, waiting for the batch to be fetched
+// unless ctx is done first. The batch is fetched with the context of the
+// first load in it, which stays alive even if that load gives up waiting
+func (b *Batcher[K, V]) Load(ctx context.Context, key K) (V, error) {
+	b.mu.Lock()
+	p := b.pending
+	if p == nil {
+		p = &batch[K, V]{ctx: context.WithoutCancel(ctx), done: make(chan struct{})}
+		p.timer = time.AfterFunc(b.cfg.Wait, func() { b.dispatch(p) })
+		b.pending = p
+	}
+
+	i := len(p.keys)
+	p.keys = append(p.keys, key)
+	if b.cfg.MaxSize > 0 && len(p.keys) >= b.cfg.MaxSize {
+		b.pending = nil
+		p.timer.Stop()
+		go b.run(p)
+	}
+	b.mu.Unlock()
+
+	var zero V
+	select {
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	case <-p.done:
+	}
+
+	if p.err != nil {
+		return zero, p.err
+	}
+	return p.values[i], nil
+}
+
+// dispatch sends p once it's waited long enough, unless it filled up first
+func (b *Batcher[K, V]) dispatch(p *batch[K, V]) {
+	b.mu.Lock()
+	if b.pending != p {
+		b.mu.Unlock()
+		return
+	}
+	b.pending = nil
+	b.mu.Unlock()
+
+	b.run(p)
+}
+
+func (b *Batcher[K, V]) run(p *batch[K, V]) {
+	defer close(p.done)
+
+	p.values, p.err = b.fetch(p.ctx, p.keys)
+	if p.err == nil && len(p.values) != len(p.keys) {
+		p.err = fmt.Errorf("%w: wanted %d, got %d", ErrBatchSize, len(p.keys), len(p.values))
+	}
+}
@@ -0,0 +1,104 @@
+package decorrt
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// loads keys concurrently through b, returning each result in key order
+func loadAll(b *Batcher[int, int], keys ...int) ([]int, []error) {
+	values, errs := make([]int, len(keys)), make([]error, len(keys))
+
+	var wg sync.WaitGroup
+	for i, k := range keys {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			values[i], errs[i] = b.Load(context.Background(), k)
+		}()
+	}
+	wg.Wait()
+
+	return values, errs
+}
+
+func TestBatcher(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		batches [][]int
+	)
+	b := NewBatcher(BatchConfig{Wait: 20 * time.Millisecond, MaxSize: 3}, func(_ context.Context, keys []int) ([]int, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		batches = append(batches, slices.Sorted(slices.Values(keys)))
+
+		values := make([]int, len(keys))
+		for i, k := range keys {
+			values[i] = k * 10
+		}
+		return values, nil
+	})
+
+	values, errs := loadAll(b, 1, 2, 3, 4, 5)
+	if !slices.Equal(values, []int{10, 20, 30, 40, 50}) || errors.Join(errs...) != nil {
+		t.Fatalf("wanted every key's value, got %v, %v", values, errs)
+	}
+
+	sizes := []int{}
+	for _, v := range batches {
+		sizes = append(sizes, len(v))
+	}
+	slices.Sort(sizes)
+	if !slices.Equal(sizes, []int{2, 3}) {
+		t.Errorf("wanted a full batch of 3 and one of the 2 left over, got %v", batches)
+	}
+}
+
+func TestBatcherErrors(mainTest *testing.T) {
+	testCases := []struct {
+		name  string
+		fetch func(context.Context, []int) ([]int, error)
+		err   error
+	}{
+		{
+			name:  "fetch fails",
+			fetch: func(context.Context, []int) ([]int, error) { return nil, errFlaky },
+			err:   errFlaky,
+		},
+		{
+			name:  "wrong size",
+			fetch: func(context.Context, []int) ([]int, error) { return []int{1}, nil },
+			err:   ErrBatchSize,
+		},
+	}
+
+	for _, tc := range testCases {
+		mainTest.Run(tc.name, func(tt *testing.T) {
+			_, errs := loadAll(NewBatcher(BatchConfig{Wait: 10 * time.Millisecond}, tc.fetch), 1, 2)
+			for _, err := range errs {
+				if !errors.Is(err, tc.err) {
+					tt.Errorf("wanted %v for every load, got %v", tc.err, err)
+				}
+			}
+		})
+	}
+}
+
+func TestBatcherContext(t *testing.T) {
+	release := make(chan struct{})
+	b := NewBatcher(BatchConfig{}, func(ctx context.Context, keys []int) ([]int, error) {
+		<-release
+		return keys, ctx.Err()
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := b.Load(ctx, 1); !errors.Is(err, context.Canceled) {
+		t.Errorf("should stop waiting once the context is done, got %v", err)
+	}
+	close(release)
+}
@@ -0,0 +1,181 @@
+package decorrt
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned instead of calling through a Breaker that's open
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// errPanicked counts a call that panicked as a failure
+var errPanicked = errors.New("panicked")
+
+// State of a Breaker
+type BreakerState int
+
+const (
+	// Calls go through, and failures are counted
+	BreakerClosed BreakerState = iota
+	// Calls fail with ErrCircuitOpen until the cool-down ends
+	BreakerOpen
+	// Trial calls go through one at a time to decide whether to close again
+	BreakerHalfOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	default:
+		return fmt.Sprintf("BreakerState(%d)", int(s))
+	}
+}
+
+// BreakerConfig tunes when a Breaker opens and closes
+type BreakerConfig struct {
+	// Consecutive failures that open the breaker. Anything below 1 counts as 1
+	FailureThreshold int
+	// How long the breaker stays open before letting a trial call through
+	CoolDown time.Duration
+	// Consecutive successful trial calls that close the breaker again.
+	// Anything below 1 counts as 1
+	SuccessThreshold int
+	// Whether an error counts as a failure. Every error does if it's nil
+	IsFailure func(error) bool
+	// Called whenever the breaker changes state, while it's locked
+	OnStateChange func(from, to BreakerState)
+}
+
+// DefaultBreakerConfig opens after 5 consecutive failures, and tries again
+// after 30 seconds
+func DefaultBreakerConfig() BreakerConfig {
+	return BreakerConfig{FailureThreshold: 5, CoolDown: 30 * time.Second, SuccessThreshold: 1}
+}
+
+// Breaker is a circuit breaker. While closed, calls go through and
+// consecutive failures are counted; enough of them open it. While open,
+// calls fail fast with ErrCircuitOpen. Once the cool-down is over it's
+// half-open, letting trial calls through one at a time: enough successes
+// close it, and any failure opens it again. It's safe for concurrent use
+type Breaker struct {
+	cfg BreakerConfig
+	now func() time.Time
+
+	mu        sync.Mutex
+	state     BreakerState
+	failures  int
+	successes int
+	openedAt  time.Time
+	probing   bool
+}
+
+func NewBreaker(cfg BreakerConfig) *Breaker {
+	return &Breaker{cfg: cfg, now: time.Now}
+}
+
+// State the breaker is in. An open breaker whose cool-down is over reports
+// that it's half-open
+func (b *Breaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.cooled()
+	return b.state
+}
+
+// Do calls fn if the breaker allows it, and counts its result. If it
+// doesn't, fn isn't called and ErrCircuitOpen is returned
+func (b *Breaker) Do(fn func() error) error {
+	trial, err := b.allow()
+	if err != nil {
+		return err
+	}
+
+	panicked := true
+	defer func() {
+		if panicked {
+			b.done(trial, errPanicked)
+		}
+	}()
+
+	err = fn()
+	panicked = false
+	b.done(trial, err)
+	return err
+}
+
+// allow reports whether a call may go through, and whether it's the trial
+// call of a half-open breaker. Only the trial call can settle that state:
+// a call let through while closed may finish after the breaker reopened
+func (b *Breaker) allow() (trial bool, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.cooled()
+	switch b.state {
+	case BreakerOpen:
+		return false, ErrCircuitOpen
+	case BreakerHalfOpen:
+		if b.probing {
+			return false, ErrCircuitOpen
+		}
+		b.probing = true
+		return true, nil
+	}
+
+	return false, nil
+}
+
+func (b *Breaker) done(trial bool, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	failed := err != nil && (b.cfg.IsFailure == nil || b.cfg.IsFailure(err))
+	switch b.state {
+	case BreakerClosed:
+		if !failed {
+			b.failures = 0
+		} else if b.failures++; b.failures >= b.cfg.FailureThreshold {
+			b.open()
+		}
+	case BreakerHalfOpen:
+		if !trial {
+			return
+		}
+
+		b.probing = false
+		if failed {
+			b.open()
+		} else if b.successes++; b.successes >= b.cfg.SuccessThreshold {
+			b.failures, b.successes = 0, 0
+			b.transition(BreakerClosed)
+		}
+	}
+}
+
+// cooled moves an open breaker to half-open once its cool-down is over
+func (b *Breaker) cooled() {
+	if b.state == BreakerOpen && b.now().Sub(b.openedAt) >= b.cfg.CoolDown {
+		b.successes = 0
+		b.transition(BreakerHalfOpen)
+	}
+}
+
+func (b *Breaker) open() {
+	b.openedAt = b.now()
+	b.transition(BreakerOpen)
+}
+
+func (b *Breaker) transition(to BreakerState) {
+	from := b.state
+	if b.state = to; from != to && b.cfg.OnStateChange != nil {
+		b.cfg.OnStateChange(from, to)
+	}
+}
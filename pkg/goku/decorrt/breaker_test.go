@@ -0,0 +1,127 @@
+package decorrt
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBreaker(t *testing.T) {
+	var transitions []string
+	b := NewBreaker(BreakerConfig{
+		FailureThreshold: 2,
+		CoolDown:         time.Minute,
+		SuccessThreshold: 2,
+		IsFailure:        func(err error) bool { return errors.Is(err, errFlaky) },
+		OnStateChange: func(from, to BreakerState) {
+			transitions = append(transitions, from.String()+"->"+to.String())
+		},
+	})
+
+	now := time.Now()
+	b.now = func() time.Time { return now }
+
+	fail := func() error { return errFlaky }
+	ok := func() error { return nil }
+	ignored := errors.New("not a failure")
+
+	steps := []struct {
+		name          string
+		fn            func() error
+		advance       time.Duration
+		expectedErr   error
+		expectedState BreakerState
+	}{
+		{name: "not a failure", fn: func() error { return ignored }, expectedErr: ignored, expectedState: BreakerClosed},
+		{name: "first failure", fn: fail, expectedErr: errFlaky, expectedState: BreakerClosed},
+		{name: "success resets", fn: ok, expectedState: BreakerClosed},
+		{name: "failure again", fn: fail, expectedErr: errFlaky, expectedState: BreakerClosed},
+		{name: "opens", fn: fail, expectedErr: errFlaky, expectedState: BreakerOpen},
+		{name: "fails fast", fn: ok, expectedErr: ErrCircuitOpen, expectedState: BreakerOpen},
+		{name: "trial fails", fn: fail, advance: time.Minute, expectedErr: errFlaky, expectedState: BreakerOpen},
+		{name: "first trial succeeds", fn: ok, advance: time.Minute, expectedState: BreakerHalfOpen},
+		{name: "closes", fn: ok, expectedState: BreakerClosed},
+	}
+
+	for _, step := range steps {
+		now = now.Add(step.advance)
+		if err := b.Do(step.fn); !errors.Is(err, step.expectedErr) || err != nil && step.expectedErr == nil {
+			t.Errorf("%s: wanted %v but got %v", step.name, step.expectedErr, err)
+		}
+
+		if got := b.State(); got != step.expectedState {
+			t.Errorf("%s: wanted state %s but got %s", step.name, step.expectedState, got)
+		}
+	}
+
+	want := []string{"closed->open", "open->half-open", "half-open->open", "open->half-open", "half-open->closed"}
+	if len(transitions) != len(want) {
+		t.Fatalf("wanted transitions %v but got %v", want, transitions)
+	}
+
+	for i, v := range want {
+		if transitions[i] != v {
+			t.Errorf("transition %d: wanted %s but got %s", i, v, transitions[i])
+		}
+	}
+}
+
+func TestBreakerOneTrialAtATime(t *testing.T) {
+	b := NewBreaker(BreakerConfig{CoolDown: time.Minute})
+	now := time.Now()
+	b.now = func() time.Time { return now }
+
+	b.Do(func() error { return errFlaky })
+	now = now.Add(time.Minute)
+
+	err := b.Do(func() error {
+		if err := b.Do(func() error { return nil }); !errors.Is(err, ErrCircuitOpen) {
+			t.Errorf("a second trial call should fail fast, got %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Errorf("the trial call should go through, got %v", err)
+	}
+
+	if got := b.State(); got != BreakerClosed {
+		t.Errorf("wanted state %s but got %s", BreakerClosed, got)
+	}
+}
+
+func TestBreakerStaleCall(t *testing.T) {
+	b := NewBreaker(BreakerConfig{CoolDown: time.Minute})
+	now := time.Now()
+	b.now = func() time.Time { return now }
+
+	b.Do(func() error {
+		b.Do(func() error { return errFlaky })
+		now = now.Add(time.Minute)
+		return nil
+	})
+
+	if got := b.State(); got != BreakerHalfOpen {
+		t.Errorf("a call let through while closed shouldn't settle a trial, wanted state %s but got %s", BreakerHalfOpen, got)
+	}
+
+	if err := b.Do(func() error { return nil }); err != nil {
+		t.Errorf("the trial call should still go through, got %v", err)
+	}
+
+	if got := b.State(); got != BreakerClosed {
+		t.Errorf("wanted state %s but got %s", BreakerClosed, got)
+	}
+}
+
+func TestBreakerPanic(t *testing.T) {
+	b := NewBreaker(BreakerConfig{CoolDown: time.Minute})
+
+	func() {
+		defer func() { recover() }()
+		b.Do(func() error { panic("boom") })
+	}()
+
+	if got := b.State(); got != BreakerOpen {
+		t.Errorf("a panic should count as a failure, wanted state %s but got %s", BreakerOpen, got)
+	}
+}
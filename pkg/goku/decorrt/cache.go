@@ -0,0 +1,111 @@
+package decorrt
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// Cache is a size bounded LRU cache whose entries expire. It's safe for
+// concurrent use
+type Cache[K comparable, V any] struct {
+	ttl  time.Duration
+	size int
+	now  func() time.Time
+
+	mu    sync.Mutex
+	order *list.List // of *cacheEntry[K, V], most recently used first
+	items map[K]*list.Element
+}
+
+type cacheEntry[K comparable, V any] struct {
+	key     K
+	value   V
+	expires time.Time
+}
+
+// NewCache holds up to size entries for ttl each. When it's full, the least
+// recently used entry is evicted to make room. A size below 1 leaves it
+// unbounded, and a ttl below 1 keeps entries until they're evicted
+func NewCache[K comparable, V any](ttl time.Duration, size int) *Cache[K, V] {
+	return &Cache[K, V]{
+		ttl:   ttl,
+		size:  size,
+		now:   time.Now,
+		order: list.New(),
+		items: map[K]*list.Element{},
+	}
+}
+
+// Get the value cached for key, if there's one that hasn't expired
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var zero V
+	elem, ok := c.items[key]
+	if !ok {
+		return zero, false
+	}
+
+	entry := elem.Value.(*cacheEntry[K, V])
+	if c.ttl > 0 && !c.now().Before(entry.expires) {
+		c.remove(elem)
+		return zero, false
+	}
+
+	c.order.MoveToFront(elem)
+	return entry.value, true
+}
+
+// Set caches value for key, evicting the least recently used entry if the
+// cache is full
+func (c *Cache[K, V]) Set(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := &cacheEntry[K, V]{key: key, value: value, expires: c.now().Add(c.ttl)}
+	if elem, ok := c.items[key]; ok {
+		elem.Value = entry
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.items[key] = c.order.PushFront(entry)
+	if c.size > 0 && c.order.Len() > c.size {
+		c.remove(c.order.Back())
+	}
+}
+
+// Delete the entry for key, if there is one
+func (c *Cache[K, V]) Delete(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.items[key]; ok {
+		c.remove(elem)
+	}
+}
+
+// Purge every entry
+func (c *Cache[K, V]) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.order.Init()
+	clear(c.items)
+}
+
+// Len is the number of entries, including any that expired but haven't been
+// looked up since
+func (c *Cache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
+
+func (c *Cache[K, V]) remove(elem *list.Element) {
+	c.order.Remove(elem)
+	delete(c.items, elem.Value.(*cacheEntry[K, V]).key)
+}
@@ -0,0 +1,72 @@
+package decorrt
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCache(mainTest *testing.T) {
+	mainTest.Run("expires", func(t *testing.T) {
+		c := NewCache[string, int](time.Minute, 0)
+		now := time.Now()
+		c.now = func() time.Time { return now }
+
+		c.Set("a", 1)
+		if got, ok := c.Get("a"); !ok || got != 1 {
+			t.Errorf("wanted 1 but got %d, %v", got, ok)
+		}
+
+		now = now.Add(time.Minute)
+		if _, ok := c.Get("a"); ok {
+			t.Error("entry should've expired")
+		}
+
+		if got := c.Len(); got != 0 {
+			t.Errorf("expired entry should've been dropped, got %d entries", got)
+		}
+	})
+
+	mainTest.Run("evicts least recently used", func(t *testing.T) {
+		c := NewCache[string, int](0, 2)
+		c.Set("a", 1)
+		c.Set("b", 2)
+		c.Get("a")
+		c.Set("c", 3)
+
+		if _, ok := c.Get("b"); ok {
+			t.Error("b was least recently used and should've been evicted")
+		}
+
+		for _, k := range []string{"a", "c"} {
+			if _, ok := c.Get(k); !ok {
+				t.Errorf("%s should still be cached", k)
+			}
+		}
+	})
+
+	mainTest.Run("overwrite", func(t *testing.T) {
+		c := NewCache[string, int](0, 2)
+		c.Set("a", 1)
+		c.Set("a", 2)
+
+		if got, _ := c.Get("a"); got != 2 || c.Len() != 1 {
+			t.Errorf("wanted a single entry of 2, got %d with %d entries", got, c.Len())
+		}
+	})
+
+	mainTest.Run("delete and purge", func(t *testing.T) {
+		c := NewCache[string, int](0, 0)
+		c.Set("a", 1)
+		c.Set("b", 2)
+
+		c.Delete("a")
+		if _, ok := c.Get("a"); ok {
+			t.Error("a should've been deleted")
+		}
+
+		c.Purge()
+		if got := c.Len(); got != 0 {
+			t.Errorf("wanted an empty cache, got %d entries", got)
+		}
+	})
+}
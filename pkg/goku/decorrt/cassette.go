@@ -0,0 +1,224 @@
+package decorrt
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+// ErrUnmatched is returned when replaying a call the cassette has no
+// recording of, or whose recordings have all been played already
+var ErrUnmatched = errors.New("no recorded call matches")
+
+// Interaction is a single recorded call. Arguments exclude a leading context
+// and results exclude a trailing error, which is kept in Error
+type Interaction struct {
+	Method  string            `json:"method"`
+	Args    []json.RawMessage `json:"args"`
+	Results []json.RawMessage `json:"results"`
+	Error   *RecordedError    `json:"error,omitempty"`
+}
+
+// RecordedError is an error as it's kept in a cassette, and as it's returned
+// when it's replayed. It keeps the messages of every error it wrapped, and
+// matches any error with one of them with errors.Is, so sentinel errors like
+// io.EOF still match after a round trip
+type RecordedError struct {
+	Message string   `json:"message"`
+	Wrapped []string `json:"wrapped,omitempty"`
+}
+
+func (e *RecordedError) Error() string { return e.Message }
+
+func (e *RecordedError) Is(target error) bool {
+	if target == nil {
+		return false
+	}
+
+	msg := target.Error()
+	for _, v := range e.Wrapped {
+		if v == msg {
+			return true
+		}
+	}
+	return msg == e.Message
+}
+
+// recordError flattens err and everything it wraps into messages
+func recordError(err error) *RecordedError {
+	if err == nil {
+		return nil
+	}
+
+	r := &RecordedError{Message: err.Error()}
+	queue := []error{err}
+	for len(queue) > 0 {
+		var wrapped []error
+		switch x := queue[0].(type) {
+		case interface{ Unwrap() error }:
+			if inner := x.Unwrap(); inner != nil {
+				wrapped = append(wrapped, inner)
+			}
+		case interface{ Unwrap() []error }:
+			wrapped = x.Unwrap()
+		}
+
+		for _, v := range wrapped {
+			r.Wrapped = append(r.Wrapped, v.Error())
+		}
+		queue = append(queue[1:], wrapped...)
+	}
+
+	return r
+}
+
+// Cassette is a list of recorded calls, kept as JSON. Record calls onto it
+// and Save it, then Load it to replay them. It's safe for concurrent use
+type Cassette struct {
+	mu           sync.Mutex
+	interactions []Interaction
+	played       []bool
+	err          error
+}
+
+func NewCassette() *Cassette { return &Cassette{} }
+
+// LoadCassette reads a cassette saved to path
+func LoadCassette(path string) (*Cassette, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file struct {
+		Interactions []Interaction `json:"interactions"`
+	}
+	if err = json.Unmarshal(b, &file); err != nil {
+		return nil, fmt.Errorf("reading cassette %s: %w", path, err)
+	}
+
+	return &Cassette{interactions: file.Interactions, played: make([]bool, len(file.Interactions))}, nil
+}
+
+// Save every recorded call to path. If any call couldn't be recorded, the
+// first reason why is returned instead
+func (c *Cassette) Save(path string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.err != nil {
+		return c.err
+	}
+
+	b, err := json.MarshalIndent(map[string]any{"interactions": c.interactions}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, append(b, '\n'), 0o644)
+}
+
+// Interactions recorded on, or loaded into, the cassette
+func (c *Cassette) Interactions() []Interaction {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]Interaction(nil), c.interactions...)
+}
+
+// Record a call to method. Values that can't be encoded as JSON aren't
+// recorded, and make Save fail
+func (c *Cassette) Record(method string, args, results []any, err error) {
+	i := Interaction{Method: method, Error: recordError(err)}
+
+	var encodeErr error
+	if i.Args, encodeErr = encodeAll(args); encodeErr == nil {
+		i.Results, encodeErr = encodeAll(results)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if encodeErr != nil {
+		if c.err == nil {
+			c.err = fmt.Errorf("recording %s: %w", method, encodeErr)
+		}
+		return
+	}
+
+	c.interactions = append(c.interactions, i)
+	c.played = append(c.played, false)
+}
+
+// Replay the first recording of a call to method with args that hasn't been
+// played yet, decoding its results into the pointers in results. It returns
+// the recorded error, or ErrUnmatched if there's no such recording
+func (c *Cassette) Replay(method string, args []any, results ...any) error {
+	encoded, err := encodeAll(args)
+	if err != nil {
+		return fmt.Errorf("replaying %s: %w", method, err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for idx, v := range c.interactions {
+		if c.played[idx] || v.Method != method || !sameJSON(v.Args, encoded) {
+			continue
+		}
+
+		if len(v.Results) != len(results) {
+			return fmt.Errorf("replaying %s: recorded %d results, but it returns %d", method, len(v.Results), len(results))
+		}
+
+		for i, r := range v.Results {
+			if err = json.Unmarshal(r, results[i]); err != nil {
+				return fmt.Errorf("replaying %s: result %d: %w", method, i, err)
+			}
+		}
+
+		c.played[idx] = true
+		if v.Error != nil {
+			return v.Error
+		}
+		return nil
+	}
+
+	raw := make([]string, len(encoded))
+	for i, v := range encoded {
+		raw[i] = string(v)
+	}
+	return fmt.Errorf("%w %s(%s)", ErrUnmatched, method, strings.Join(raw, ", "))
+}
+
+func encodeAll(values []any) ([]json.RawMessage, error) {
+	encoded := make([]json.RawMessage, len(values))
+	for i, v := range values {
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		encoded[i] = b
+	}
+	return encoded, nil
+}
+
+// sameJSON compares encoded values ignoring whitespace, since saving a
+// cassette indents them
+func sameJSON(a, b []json.RawMessage) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		var x, y bytes.Buffer
+		if json.Compact(&x, a[i]) != nil || json.Compact(&y, b[i]) != nil || !bytes.Equal(x.Bytes(), y.Bytes()) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,60 @@
+package decorrt
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"testing"
+)
+
+func TestCassette(t *testing.T) {
+	type user struct{ ID int }
+
+	c := NewCassette()
+	c.Record("Get", []any{1}, []any{user{ID: 1}}, nil)
+	c.Record("Get", []any{2}, []any{user{}}, fmt.Errorf("get 2: %w", io.EOF))
+	c.Record("Count", nil, []any{1}, nil)
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	if err := c.Save(path); err != nil {
+		t.Fatalf("should save, got %v", err)
+	}
+
+	c, err := LoadCassette(path)
+	if err != nil {
+		t.Fatalf("should load, got %v", err)
+	}
+
+	var u user
+	if err = c.Replay("Get", []any{1}, &u); err != nil || u.ID != 1 {
+		t.Errorf("wanted user 1, got %+v, %v", u, err)
+	}
+
+	err = c.Replay("Get", []any{2}, &u)
+	if !errors.Is(err, io.EOF) || err.Error() != "get 2: EOF" {
+		t.Errorf("wanted the recorded error to round trip, got %v", err)
+	}
+
+	var n int
+	if err = c.Replay("Count", nil, &n); err != nil || n != 1 {
+		t.Errorf("wanted 1, got %d, %v", n, err)
+	}
+
+	if err = c.Replay("Get", []any{1}, &u); !errors.Is(err, ErrUnmatched) {
+		t.Errorf("recordings should only play once, got %v", err)
+	}
+
+	if err = c.Replay("Get", []any{3}, &u); !errors.Is(err, ErrUnmatched) {
+		t.Errorf("wanted %v, got %v", ErrUnmatched, err)
+	}
+}
+
+func TestCassetteUnencodable(t *testing.T) {
+	c := NewCassette()
+	c.Record("Do", []any{func() {}}, nil, nil)
+
+	if err := c.Save(filepath.Join(t.TempDir(), "cassette.json")); err == nil {
+		t.Error("saving should fail when a call couldn't be recorded")
+	}
+}
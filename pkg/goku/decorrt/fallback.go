@@ -0,0 +1,34 @@
+package decorrt
+
+import "errors"
+
+// ErrNoFallbacks is returned by Fallback when it has no implementations to try
+var ErrNoFallbacks = errors.New("no implementations to fall back on")
+
+// Fallback tries each of n implementations in order, by passing its index to
+// call, until one succeeds. An error only moves on to the next one if
+// classify says so; a nil classify falls through on every error. If none
+// succeed, every error they returned is joined together, unless only one was
+// tried, whose error is returned as it is
+func Fallback(n int, classify func(error) bool, call func(i int) error) error {
+	if n == 0 {
+		return ErrNoFallbacks
+	}
+
+	var errs []error
+	for i := range n {
+		err := call(i)
+		if err == nil {
+			return nil
+		}
+
+		if errs = append(errs, err); classify != nil && !classify(err) {
+			break
+		}
+	}
+
+	if len(errs) == 1 {
+		return errs[0]
+	}
+	return errors.Join(errs...)
+}
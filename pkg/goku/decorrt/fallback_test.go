@@ -0,0 +1,58 @@
+package decorrt
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFallback(mainTest *testing.T) {
+	errFatal := errors.New("fatal")
+
+	testCases := []struct {
+		name          string
+		results       []error
+		classify      func(error) bool
+		expectedCalls int
+		expectedErr   []error
+	}{
+		{name: "none", expectedErr: []error{ErrNoFallbacks}},
+		{name: "first succeeds", results: []error{nil, errFlaky}, expectedCalls: 1},
+		{name: "falls through", results: []error{errFlaky, nil}, expectedCalls: 2},
+		{name: "all fail", results: []error{errFlaky, errFatal}, expectedCalls: 2, expectedErr: []error{errFlaky, errFatal}},
+		{
+			name:          "classified",
+			results:       []error{errFatal, nil},
+			classify:      func(err error) bool { return !errors.Is(err, errFatal) },
+			expectedCalls: 1,
+			expectedErr:   []error{errFatal},
+		},
+	}
+
+	for _, tc := range testCases {
+		mainTest.Run(tc.name, func(tt *testing.T) {
+			calls := 0
+			err := Fallback(len(tc.results), tc.classify, func(i int) error {
+				calls++
+				return tc.results[i]
+			})
+
+			if calls != tc.expectedCalls {
+				tt.Errorf("wanted %d calls but got %d", tc.expectedCalls, calls)
+			}
+
+			if len(tc.expectedErr) == 0 && err != nil {
+				tt.Errorf("wanted no error but got %v", err)
+			}
+
+			if len(tc.expectedErr) == 1 && err != tc.expectedErr[0] {
+				tt.Errorf("a single error should be returned unchanged, wanted %v but got %#v", tc.expectedErr[0], err)
+			}
+
+			for _, v := range tc.expectedErr {
+				if !errors.Is(err, v) {
+					tt.Errorf("wanted %v but got %v", v, err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package decorrt
+
+import (
+	"context"
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"time"
+)
+
+// FaultRule picks calls to inject a fault into, and what to inject. A rule
+// with none of Err, Latency and Panic set injects nothing, but still stops
+// rules after it from matching
+type FaultRule struct {
+	// Method the rule applies to. Empty applies it to every method
+	Method string
+	// Chance a matching call is injected, from 0 to 1. Zero, with Calls
+	// empty, injects every matching call
+	Probability float64
+	// Only calls with these numbers, counting each method's calls from 1,
+	// match. Empty matches every call
+	Calls []int
+
+	// Returned through the method's error result. Methods that can't return
+	// an error don't get it
+	Err error
+	// How long to wait before calling through, or returning Err
+	Latency time.Duration
+	// If set, the call panics with it after Latency
+	Panic any
+}
+
+// Faults injects faults into calls by a set of rules, using a seeded random
+// number generator so runs are reproducible. It's safe for concurrent use,
+// though concurrent calls race for the numbers the generator hands out
+type Faults struct {
+	rules []FaultRule
+
+	mu    sync.Mutex
+	rng   *rand.Rand
+	calls map[string]int
+}
+
+// NewFaults injects faults by rules, trying them in order. The first one
+// that matches a call decides its fault
+func NewFaults(seed uint64, rules ...FaultRule) *Faults {
+	return &Faults{
+		rules: rules,
+		rng:   rand.New(rand.NewPCG(seed, seed)),
+		calls: map[string]int{},
+	}
+}
+
+// Inject the fault for this call to method, if a rule picks it. It waits out
+// the rule's latency, giving up with ctx's error if it's done first, panics
+// if the rule says to, and otherwise returns the rule's error
+func (f *Faults) Inject(ctx context.Context, method string) error {
+	rule, ok := f.match(method)
+	if !ok {
+		return nil
+	}
+
+	if rule.Latency > 0 {
+		t := time.NewTimer(rule.Latency)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+
+	if rule.Panic != nil {
+		panic(rule.Panic)
+	}
+
+	return rule.Err
+}
+
+// Calls counts the calls made to method so far
+func (f *Faults) Calls(method string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls[method]
+}
+
+func (f *Faults) match(method string) (FaultRule, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.calls[method]++
+	n := f.calls[method]
+	for _, v := range f.rules {
+		if v.Method != "" && v.Method != method {
+			continue
+		}
+
+		if len(v.Calls) > 0 && !slices.Contains(v.Calls, n) {
+			continue
+		}
+
+		if v.Probability > 0 && f.rng.Float64() >= v.Probability {
+			continue
+		}
+
+		return v, true
+	}
+
+	return FaultRule{}, false
+}
@@ -0,0 +1,74 @@
+package decorrt
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestFaults(t *testing.T) {
+	f := NewFaults(1,
+		FaultRule{Method: "Get", Calls: []int{2, 3}, Err: errFlaky},
+		FaultRule{Method: "Put", Panic: "boom"},
+	)
+
+	for i, want := range []error{nil, errFlaky, errFlaky, nil} {
+		if got := f.Inject(context.Background(), "Get"); got != want {
+			t.Errorf("call %d: wanted %v, got %v", i+1, want, got)
+		}
+	}
+
+	if got := f.Inject(context.Background(), "Delete"); got != nil {
+		t.Errorf("no rule matches Delete, got %v", got)
+	}
+
+	func() {
+		defer func() {
+			if v := recover(); v != "boom" {
+				t.Errorf("wanted a panic with boom, got %v", v)
+			}
+		}()
+		f.Inject(context.Background(), "Put")
+	}()
+
+	if got := f.Calls("Get"); got != 4 {
+		t.Errorf("wanted 4 calls to Get counted, got %d", got)
+	}
+}
+
+func TestFaultsProbability(t *testing.T) {
+	run := func() (injected []bool) {
+		f := NewFaults(42, FaultRule{Probability: 0.5, Err: errFlaky})
+		for range 100 {
+			injected = append(injected, f.Inject(context.Background(), "Get") != nil)
+		}
+		return injected
+	}
+
+	first, second := run(), run()
+	count := 0
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("runs with the same seed should inject the same calls, call %d differs", i+1)
+		}
+		if first[i] {
+			count++
+		}
+	}
+
+	if count < 30 || count > 70 {
+		t.Errorf("wanted about half the calls injected, got %d of 100", count)
+	}
+}
+
+func TestFaultsLatency(t *testing.T) {
+	f := NewFaults(1, FaultRule{Latency: time.Hour})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+
+	if err := f.Inject(ctx, "Get"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("latency should be cut short by the context, got %v", err)
+	}
+}
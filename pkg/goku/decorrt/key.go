@@ -0,0 +1,87 @@
+package decorrt
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Key prints values so that two calls share a key only if their arguments
+// are alike in both type and value: 1 and int64(1) don't, not even inside
+// a []any. Slices, maps and structs are printed element by element, with
+// map entries sorted, while pointers are printed by address. Funcs and
+// channels can't be told apart by value, so Key panics on them rather than
+// let different calls share a key
+func Key(values ...any) string {
+	var b strings.Builder
+	for i, v := range values {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		writeKey(&b, reflect.ValueOf(v))
+	}
+	return b.String()
+}
+
+func writeKey(b *strings.Builder, v reflect.Value) {
+	if !v.IsValid() {
+		b.WriteString("nil")
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Interface:
+		writeKey(b, v.Elem())
+	case reflect.Slice, reflect.Map:
+		if v.IsNil() {
+			fmt.Fprintf(b, "%s(nil)", v.Type())
+			return
+		}
+
+		if v.Kind() == reflect.Slice {
+			writeElems(b, v)
+			return
+		}
+
+		entries := make([]string, 0, v.Len())
+		for iter := v.MapRange(); iter.Next(); {
+			var entry strings.Builder
+			writeKey(&entry, iter.Key())
+			entry.WriteString(": ")
+			writeKey(&entry, iter.Value())
+			entries = append(entries, entry.String())
+		}
+		slices.Sort(entries)
+		fmt.Fprintf(b, "%s{%s}", v.Type(), strings.Join(entries, ", "))
+	case reflect.Array:
+		writeElems(b, v)
+	case reflect.Struct:
+		fmt.Fprintf(b, "%s{", v.Type())
+		for i := range v.NumField() {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteString(v.Type().Field(i).Name + ": ")
+			writeKey(b, v.Field(i))
+		}
+		b.WriteByte('}')
+	case reflect.Pointer:
+		fmt.Fprintf(b, "%s(%#x)", v.Type(), v.Pointer())
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		panic(fmt.Sprintf("decorrt: %s can't be part of a key", v.Type()))
+	default:
+		fmt.Fprintf(b, "%s(%#v)", v.Type(), v)
+	}
+}
+
+func writeElems(b *strings.Builder, v reflect.Value) {
+	fmt.Fprintf(b, "%s{", v.Type())
+	for i := range v.Len() {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		writeKey(b, v.Index(i))
+	}
+	b.WriteByte('}')
+}
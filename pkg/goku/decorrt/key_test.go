@@ -0,0 +1,54 @@
+package decorrt
+
+import "testing"
+
+type keyID int
+
+type keyPoint struct {
+	X, y int
+}
+
+func TestKey(mainTest *testing.T) {
+	testCases := []struct {
+		name   string
+		values []any
+		want   string
+	}{
+		{name: "none", want: ""},
+		{name: "basic", values: []any{1, "a"}, want: `int(1), string("a")`},
+		{name: "named", values: []any{keyID(1)}, want: "decorrt.keyID(1)"},
+		{name: "nil", values: []any{nil, []int(nil)}, want: "nil, []int(nil)"},
+		{name: "slice of any", values: []any{[]any{1, int64(1)}}, want: "[]interface {}{int(1), int64(1)}"},
+		{name: "map sorted", values: []any{map[string]int{"b": 2, "a": 1}}, want: `map[string]int{string("a"): int(1), string("b"): int(2)}`},
+		{name: "struct", values: []any{keyPoint{X: 1, y: 2}}, want: "decorrt.keyPoint{X: int(1), y: int(2)}"},
+		{name: "array", values: []any{[2]uint8{1, 2}}, want: "[2]uint8{uint8(0x1), uint8(0x2)}"},
+	}
+
+	for _, tc := range testCases {
+		mainTest.Run(tc.name, func(tt *testing.T) {
+			if got := Key(tc.values...); got != tc.want {
+				tt.Errorf("wanted %s, got %s", tc.want, got)
+			}
+		})
+	}
+
+	if Key(1) == Key(int64(1)) {
+		mainTest.Error("values of different types shouldn't share a key")
+	}
+
+	x, y := 1, 1
+	if Key(&x) == Key(&y) {
+		mainTest.Error("pointers should be keyed by address")
+	}
+
+	for _, v := range []any{func() {}, make(chan int), []any{func() {}}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					mainTest.Errorf("keying %T should panic", v)
+				}
+			}()
+			Key(v)
+		}()
+	}
+}
@@ -0,0 +1,134 @@
+package decorrt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrLimited is returned instead of making a call a Limiter won't allow. When
+// the limiter gave up waiting because the call's context was done, the error
+// also matches the context's
+var ErrLimited = errors.New("rate limit exceeded")
+
+// LimitConfig tunes how many calls a Limiter allows. The zero value allows
+// everything
+type LimitConfig struct {
+	// Calls allowed per second on average. Zero leaves the rate unlimited
+	Rate float64
+	// Calls that can be made at once after a quiet period. Anything below 1
+	// counts as 1
+	Burst int
+	// Calls allowed to run at the same time. Zero leaves it unlimited
+	MaxInFlight int
+}
+
+// Limiter limits calls with a token bucket, a cap on how many run at once,
+// or both. It's safe for concurrent use
+type Limiter struct {
+	rate  float64
+	burst float64
+	now   func() time.Time
+	slots chan struct{}
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+func NewLimiter(cfg LimitConfig) *Limiter {
+	l := &Limiter{rate: cfg.Rate, burst: float64(max(cfg.Burst, 1)), now: time.Now}
+	l.tokens, l.last = l.burst, l.now()
+
+	if cfg.MaxInFlight > 0 {
+		l.slots = make(chan struct{}, cfg.MaxInFlight)
+	}
+
+	return l
+}
+
+// Do calls fn once the limiter allows it, waiting as long as it takes unless
+// ctx is done first
+func (l *Limiter) Do(ctx context.Context, fn func() error) error {
+	if wait := l.reserve(true); wait > 0 {
+		t := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			l.unreserve()
+			return fmt.Errorf("%w: %w", ErrLimited, ctx.Err())
+		case <-t.C:
+		}
+	}
+
+	if l.slots != nil {
+		select {
+		case l.slots <- struct{}{}:
+		case <-ctx.Done():
+			l.unreserve()
+			return fmt.Errorf("%w: %w", ErrLimited, ctx.Err())
+		}
+		defer func() { <-l.slots }()
+	}
+
+	return fn()
+}
+
+// TryDo calls fn if the limiter allows it right away, and returns ErrLimited
+// if it doesn't
+func (l *Limiter) TryDo(fn func() error) error {
+	if l.reserve(false) < 0 {
+		return ErrLimited
+	}
+
+	if l.slots != nil {
+		select {
+		case l.slots <- struct{}{}:
+		default:
+			l.unreserve()
+			return ErrLimited
+		}
+		defer func() { <-l.slots }()
+	}
+
+	return fn()
+}
+
+// reserve takes a token from the bucket, returning how long it is until the
+// token would've been there. If it shouldn't wait, no token is taken when
+// there isn't one, and the wait is negative
+func (l *Limiter) reserve(wait bool) time.Duration {
+	if l.rate <= 0 {
+		return 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.tokens = min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+
+	if l.tokens < 1 && !wait {
+		return -1
+	}
+
+	if l.tokens--; l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// unreserve gives back a token taken for a call that was never made
+func (l *Limiter) unreserve() {
+	if l.rate <= 0 {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.tokens = min(l.burst, l.tokens+1)
+}
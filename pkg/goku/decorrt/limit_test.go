@@ -0,0 +1,89 @@
+package decorrt
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLimiterRate(t *testing.T) {
+	l := NewLimiter(LimitConfig{Rate: 10, Burst: 2})
+	now := l.last
+	l.now = func() time.Time { return now }
+
+	steps := []struct {
+		name     string
+		advance  time.Duration
+		wait     bool
+		expected time.Duration
+	}{
+		{name: "burst", expected: 0},
+		{name: "rest of burst", expected: 0},
+		{name: "fail fast", expected: -1},
+		{name: "wait for the next token", wait: true, expected: 100 * time.Millisecond},
+		{name: "refilled", advance: 300 * time.Millisecond, expected: 0},
+		{name: "refilled up to burst", expected: 0},
+		{name: "empty again", expected: -1},
+	}
+
+	for _, step := range steps {
+		now = now.Add(step.advance)
+		if got := l.reserve(step.wait); got != step.expected {
+			t.Errorf("%s: wanted %s but got %s", step.name, step.expected, got)
+		}
+	}
+}
+
+func TestLimiterDo(mainTest *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	ok := func() error { return nil }
+
+	mainTest.Run("unlimited", func(t *testing.T) {
+		l := NewLimiter(LimitConfig{})
+		for range 100 {
+			if err := l.TryDo(ok); err != nil {
+				t.Fatalf("wanted no error but got %v", err)
+			}
+		}
+	})
+
+	mainTest.Run("rate", func(t *testing.T) {
+		l := NewLimiter(LimitConfig{Rate: 0.001})
+		if err := l.TryDo(ok); err != nil {
+			t.Fatalf("the first call should be allowed, got %v", err)
+		}
+
+		if err := l.TryDo(ok); !errors.Is(err, ErrLimited) {
+			t.Errorf("wanted %v but got %v", ErrLimited, err)
+		}
+
+		err := l.Do(canceled, ok)
+		if !errors.Is(err, ErrLimited) || !errors.Is(err, context.Canceled) {
+			t.Errorf("wanted %v and %v but got %v", ErrLimited, context.Canceled, err)
+		}
+	})
+
+	mainTest.Run("in flight", func(t *testing.T) {
+		l := NewLimiter(LimitConfig{MaxInFlight: 1})
+		err := l.Do(context.Background(), func() error {
+			if err := l.TryDo(ok); !errors.Is(err, ErrLimited) {
+				t.Errorf("wanted %v but got %v", ErrLimited, err)
+			}
+
+			if err := l.Do(canceled, ok); !errors.Is(err, context.Canceled) {
+				t.Errorf("wanted %v but got %v", context.Canceled, err)
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("wanted no error but got %v", err)
+		}
+
+		if err = l.TryDo(ok); err != nil {
+			t.Errorf("the slot should've been released, got %v", err)
+		}
+	})
+}
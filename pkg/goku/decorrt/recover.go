@@ -0,0 +1,29 @@
+package decorrt
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// PanicError is a panic recovered from a call
+type PanicError struct {
+	Method string
+	// What was passed to panic
+	Value any
+	// Stack trace of the goroutine that panicked, as it was when recovered
+	Stack []byte
+}
+
+// Recovered captures the stack of a panic recovered from a call to method.
+// Call it from the deferred function that recovered
+func Recovered(method string, value any) *PanicError {
+	return &PanicError{Method: method, Value: value, Stack: debug.Stack()}
+}
+
+func (e *PanicError) Error() string { return fmt.Sprintf("%s panicked: %v", e.Method, e.Value) }
+
+// Unwrap returns the panic's value if it was an error
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
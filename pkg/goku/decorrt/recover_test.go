@@ -0,0 +1,27 @@
+package decorrt
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestRecovered(t *testing.T) {
+	var err *PanicError
+	func() {
+		defer func() { err = Recovered("Get", recover()) }()
+		panic(errFlaky)
+	}()
+
+	if got := err.Error(); got != "Get panicked: flaky" {
+		t.Errorf("wanted Get panicked: flaky, got %s", got)
+	}
+
+	if !errors.Is(err, errFlaky) {
+		t.Errorf("should unwrap to the error it panicked with, got %v", err)
+	}
+
+	if !bytes.Contains(err.Stack, []byte("TestRecovered")) {
+		t.Errorf("the stack should include where it panicked, got\n%s", err.Stack)
+	}
+}
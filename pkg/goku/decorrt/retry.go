@@ -0,0 +1,95 @@
+// Package decorrt is the runtime shared by the decorators goku generates:
+// policies and state that would otherwise be duplicated in every generated
+// wrapper live here, so generated methods stay thin
+package decorrt
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// RetryPolicy decides how many times a failing call is attempted and how long
+// to wait between attempts. The zero value attempts calls once
+type RetryPolicy struct {
+	// Attempts in total, including the first. Anything below 1 counts as 1
+	MaxAttempts int
+	// Wait before the first retry. It doubles after every retry after that
+	BaseDelay time.Duration
+	// Upper bound on the wait between attempts. Zero leaves it unbounded
+	MaxDelay time.Duration
+	// Fraction of each wait, from 0 to 1, that's randomized so callers
+	// retrying together spread out
+	Jitter float64
+	// Whether an error is worth retrying. Every error is if it's nil
+	Retryable func(error) bool
+}
+
+// DefaultRetryPolicy attempts calls 3 times, waiting 100ms then 200ms with
+// 20% jitter, and retries every error
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   100 * time.Millisecond,
+		MaxDelay:    5 * time.Second,
+		Jitter:      0.2,
+	}
+}
+
+// longestDelay is where Backoff saturates instead of overflowing
+const longestDelay = time.Duration(math.MaxInt64)
+
+// Backoff is the wait before retry n, starting from 1. Without a MaxDelay it
+// stops growing at the longest time.Duration rather than overflowing
+func (p RetryPolicy) Backoff(n int) time.Duration {
+	d := p.BaseDelay
+	for i := 1; i < n && (p.MaxDelay <= 0 || d < p.MaxDelay); i++ {
+		if d > longestDelay/2 {
+			d = longestDelay
+			break
+		}
+		d *= 2
+	}
+
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+
+	if jitter := min(max(p.Jitter, 0), 1); jitter > 0 && d > 0 {
+		spread := d
+		if s := float64(d) * jitter; s < float64(longestDelay) {
+			spread = time.Duration(s)
+		}
+
+		offset := time.Duration(rand.Uint64N(uint64(spread) + 1))
+		if rand.IntN(2) == 0 {
+			d -= offset
+		} else {
+			d = min(d, longestDelay-offset) + offset
+		}
+	}
+
+	return d
+}
+
+// Do calls fn until it returns nil, returns an error that isn't retryable, or
+// the policy runs out of attempts, returning fn's last error. If ctx is done
+// while waiting to retry, the last error is joined with ctx.Err()
+func (p RetryPolicy) Do(ctx context.Context, fn func() error) error {
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || attempt >= p.MaxAttempts || p.Retryable != nil && !p.Retryable(err) {
+			return err
+		}
+
+		t := time.NewTimer(p.Backoff(attempt))
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return errors.Join(err, ctx.Err())
+		case <-t.C:
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package decorrt
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+var errFlaky = errors.New("flaky")
+
+func TestRetryDo(mainTest *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	testCases := []struct {
+		name          string
+		policy        RetryPolicy
+		ctx           context.Context
+		failures      int
+		expectedCalls int
+		expectedErr   []error
+	}{
+		{name: "zero value", failures: 5, expectedCalls: 1, expectedErr: []error{errFlaky}},
+		{name: "succeeds", policy: RetryPolicy{MaxAttempts: 3}, expectedCalls: 1},
+		{name: "recovers", policy: RetryPolicy{MaxAttempts: 3}, failures: 2, expectedCalls: 3},
+		{name: "runs out", policy: RetryPolicy{MaxAttempts: 3}, failures: 5, expectedCalls: 3, expectedErr: []error{errFlaky}},
+		{
+			name:          "not retryable",
+			policy:        RetryPolicy{MaxAttempts: 3, Retryable: func(err error) bool { return !errors.Is(err, errFlaky) }},
+			failures:      5,
+			expectedCalls: 1,
+			expectedErr:   []error{errFlaky},
+		},
+		{
+			name:          "context done",
+			policy:        RetryPolicy{MaxAttempts: 3, BaseDelay: time.Hour},
+			ctx:           canceled,
+			failures:      5,
+			expectedCalls: 1,
+			expectedErr:   []error{errFlaky, context.Canceled},
+		},
+	}
+
+	for _, tc := range testCases {
+		mainTest.Run(tc.name, func(tt *testing.T) {
+			if tc.ctx == nil {
+				tc.ctx = context.Background()
+			}
+
+			calls := 0
+			err := tc.policy.Do(tc.ctx, func() error {
+				if calls++; calls <= tc.failures {
+					return errFlaky
+				}
+				return nil
+			})
+
+			if calls != tc.expectedCalls {
+				tt.Errorf("wanted %d calls but got %d", tc.expectedCalls, calls)
+			}
+
+			if len(tc.expectedErr) == 0 && err != nil {
+				tt.Errorf("wanted no error but got %v", err)
+			}
+
+			for _, v := range tc.expectedErr {
+				if !errors.Is(err, v) {
+					tt.Errorf("wanted %v but got %v", v, err)
+				}
+			}
+		})
+	}
+}
+
+func TestBackoff(mainTest *testing.T) {
+	testCases := []struct {
+		name     string
+		policy   RetryPolicy
+		n        int
+		min, max time.Duration
+	}{
+		{name: "first", policy: RetryPolicy{BaseDelay: time.Second}, n: 1, min: time.Second, max: time.Second},
+		{name: "doubles", policy: RetryPolicy{BaseDelay: time.Second}, n: 3, min: 4 * time.Second, max: 4 * time.Second},
+		{name: "capped", policy: RetryPolicy{BaseDelay: time.Second, MaxDelay: 3 * time.Second}, n: 10, min: 3 * time.Second, max: 3 * time.Second},
+		{name: "jitter", policy: RetryPolicy{BaseDelay: time.Second, Jitter: 0.5}, n: 1, min: time.Second / 2, max: 3 * time.Second / 2},
+		{name: "saturates", policy: RetryPolicy{BaseDelay: time.Second}, n: 100, min: longestDelay, max: longestDelay},
+		{name: "saturates with jitter", policy: RetryPolicy{BaseDelay: time.Second, Jitter: 1}, n: 100, min: 0, max: longestDelay},
+	}
+
+	for _, tc := range testCases {
+		mainTest.Run(tc.name, func(tt *testing.T) {
+			for range 100 {
+				if got := tc.policy.Backoff(tc.n); got < tc.min || got > tc.max {
+					tt.Fatalf("wanted a backoff in [%s, %s] but got %s", tc.min, tc.max, got)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package decorrt
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Mismatch is a call a candidate answered differently from the primary it
+// shadows
+type Mismatch struct {
+	Method string
+	// Arguments of the call, excluding a leading context
+	Args []any
+	// Results of the call from each implementation
+	Primary, Candidate []any
+	// Set instead of Candidate if the candidate panicked
+	Panic *PanicError
+}
+
+func (m Mismatch) String() string {
+	if m.Panic != nil {
+		return fmt.Sprintf("%s%v: candidate panicked: %v", m.Method, m.Args, m.Panic.Value)
+	}
+	return fmt.Sprintf("%s%v: primary returned %v, candidate returned %v", m.Method, m.Args, m.Primary, m.Candidate)
+}
+
+// Shadow runs calls to a candidate implementation in the background and
+// reports the ones that don't match the primary
+type Shadow struct {
+	report func(Mismatch)
+	wg     sync.WaitGroup
+}
+
+// NewShadow reports mismatches to report, which may be called from several
+// goroutines at once
+func NewShadow(report func(Mismatch)) *Shadow { return &Shadow{report: report} }
+
+// Go runs call in the background. It returns the mismatch to report, or nil
+// if the candidate agreed. A panic is reported as a mismatch rather than
+// crashing the program
+func (s *Shadow) Go(method string, args []any, call func() *Mismatch) {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer func() {
+			if v := recover(); v != nil {
+				s.report(Mismatch{Method: method, Args: args, Panic: Recovered(method, v)})
+			}
+		}()
+
+		if m := call(); m != nil {
+			s.report(*m)
+		}
+	}()
+}
+
+// Wait for every call running in the background to finish
+func (s *Shadow) Wait() { s.wg.Wait() }
+
+// SameError reports whether a and b are both nil, or both errors with the
+// same message. Implementations rarely return the same error values, so
+// comparing them directly would report mismatches that aren't
+func SameError(a, b error) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Error() == b.Error()
+}
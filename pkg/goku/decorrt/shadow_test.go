@@ -0,0 +1,66 @@
+package decorrt
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestShadow(t *testing.T) {
+	var (
+		mu         sync.Mutex
+		mismatches []Mismatch
+	)
+	s := NewShadow(func(m Mismatch) {
+		mu.Lock()
+		defer mu.Unlock()
+		mismatches = append(mismatches, m)
+	})
+
+	s.Go("Agree", nil, func() *Mismatch { return nil })
+	s.Go("Differ", []any{1}, func() *Mismatch {
+		return &Mismatch{Method: "Differ", Args: []any{1}, Primary: []any{1}, Candidate: []any{2}}
+	})
+	s.Go("Panic", []any{2}, func() *Mismatch { panic("boom") })
+	s.Wait()
+
+	if len(mismatches) != 2 {
+		t.Fatalf("wanted 2 mismatches, got %v", mismatches)
+	}
+
+	for _, m := range mismatches {
+		switch m.Method {
+		case "Differ":
+			if got := m.String(); got != "Differ[1]: primary returned [1], candidate returned [2]" {
+				t.Errorf("unexpected mismatch %s", got)
+			}
+		case "Panic":
+			if m.Panic == nil || m.Panic.Value != "boom" {
+				t.Errorf("wanted the panic reported, got %+v", m)
+			}
+		default:
+			t.Errorf("unexpected mismatch %v", m)
+		}
+	}
+}
+
+func TestSameError(mainTest *testing.T) {
+	testCases := []struct {
+		name     string
+		a, b     error
+		expected bool
+	}{
+		{name: "nil", expected: true},
+		{name: "one nil", a: errFlaky},
+		{name: "same message", a: errFlaky, b: errors.New("flaky"), expected: true},
+		{name: "different message", a: errFlaky, b: errors.New("other")},
+	}
+
+	for _, tc := range testCases {
+		mainTest.Run(tc.name, func(tt *testing.T) {
+			if got := SameError(tc.a, tc.b); got != tc.expected {
+				tt.Errorf("wanted %v but got %v", tc.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package decorrt
+
+import (
+	"errors"
+	"sync"
+)
+
+// TeePolicy decides which error a call to every implementation behind a tee
+// returns. Other results always come from the first implementation
+type TeePolicy int
+
+const (
+	// Return the first implementation's error, ignoring the others'
+	TeeFirstResult TeePolicy = iota
+	// Return the first error any implementation returned, in the order
+	// they were given
+	TeeFirstError
+	// Return every implementation's error, joined with errors.Join
+	TeeJoinErrors
+)
+
+// TeeConfig tunes how a tee calls its implementations
+type TeeConfig struct {
+	Policy TeePolicy
+	// Call every implementation at once rather than one after the other
+	Parallel bool
+}
+
+// Tee makes a call to each of n implementations, by passing its index to
+// call, and returns the error cfg.Policy picks. Every implementation is
+// called no matter what the others return
+func Tee(cfg TeeConfig, n int, call func(i int) error) error {
+	errs := make([]error, n)
+	if cfg.Parallel {
+		var wg sync.WaitGroup
+		wg.Add(n)
+		for i := range n {
+			go func() {
+				defer wg.Done()
+				errs[i] = call(i)
+			}()
+		}
+		wg.Wait()
+	} else {
+		for i := range n {
+			errs[i] = call(i)
+		}
+	}
+
+	if n == 0 {
+		return nil
+	}
+
+	switch cfg.Policy {
+	case TeeFirstError:
+		for _, v := range errs {
+			if v != nil {
+				return v
+			}
+		}
+		return nil
+	case TeeJoinErrors:
+		return errors.Join(errs...)
+	default:
+		return errs[0]
+	}
+}
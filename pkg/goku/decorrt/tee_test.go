@@ -0,0 +1,58 @@
+package decorrt
+
+import (
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestTee(mainTest *testing.T) {
+	errSecond, errThird := errors.New("second"), errors.New("third")
+	results := []error{nil, errSecond, errThird}
+
+	testCases := []struct {
+		name        string
+		cfg         TeeConfig
+		expectedErr []error
+	}{
+		{name: "first result", cfg: TeeConfig{Policy: TeeFirstResult}},
+		{name: "first error", cfg: TeeConfig{Policy: TeeFirstError}, expectedErr: []error{errSecond}},
+		{name: "join", cfg: TeeConfig{Policy: TeeJoinErrors}, expectedErr: []error{errSecond, errThird}},
+		{name: "parallel", cfg: TeeConfig{Policy: TeeJoinErrors, Parallel: true}, expectedErr: []error{errSecond, errThird}},
+	}
+
+	for _, tc := range testCases {
+		mainTest.Run(tc.name, func(tt *testing.T) {
+			var mu sync.Mutex
+			var called []int
+
+			err := Tee(tc.cfg, len(results), func(i int) error {
+				mu.Lock()
+				defer mu.Unlock()
+
+				called = append(called, i)
+				return results[i]
+			})
+
+			slices.Sort(called)
+			if !slices.Equal(called, []int{0, 1, 2}) {
+				tt.Errorf("every implementation should be called once, got %v", called)
+			}
+
+			if len(tc.expectedErr) == 0 && err != nil {
+				tt.Errorf("wanted no error but got %v", err)
+			}
+
+			for _, v := range tc.expectedErr {
+				if !errors.Is(err, v) {
+					tt.Errorf("wanted %v but got %v", v, err)
+				}
+			}
+
+			if tc.cfg.Policy == TeeFirstError && errors.Is(err, errThird) {
+				tt.Errorf("only the first error should be returned, got %v", err)
+			}
+		})
+	}
+}
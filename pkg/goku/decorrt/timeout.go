@@ -0,0 +1,34 @@
+package decorrt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// TimeoutError is a call's error when it failed after running out of the time
+// a timeout decorator gave it, but didn't say so itself. It matches both
+// context.DeadlineExceeded and the call's own error with errors.Is
+type TimeoutError struct {
+	Method  string
+	Timeout time.Duration
+	Err     error
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("%s timed out after %s: %v", e.Method, e.Timeout, e.Err)
+}
+
+func (e *TimeoutError) Unwrap() []error { return []error{context.DeadlineExceeded, e.Err} }
+
+// TimedOut makes sure err matches context.DeadlineExceeded if ctx's deadline
+// passed, wrapping it in a *TimeoutError when it doesn't already. nil and
+// errors returned before the deadline are left alone
+func TimedOut(ctx context.Context, method string, timeout time.Duration, err error) error {
+	if err == nil || ctx.Err() != context.DeadlineExceeded || errors.Is(err, context.DeadlineExceeded) {
+		return err
+	}
+
+	return &TimeoutError{Method: method, Timeout: timeout, Err: err}
+}
@@ -0,0 +1,50 @@
+package decorrt
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTimedOut(mainTest *testing.T) {
+	expired, cancel := context.WithTimeout(context.Background(), -time.Second)
+	defer cancel()
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	testCases := []struct {
+		name        string
+		ctx         context.Context
+		err         error
+		expectedErr []error
+		wrapped     bool
+	}{
+		{name: "nil", ctx: expired},
+		{name: "in time", ctx: context.Background(), err: errFlaky, expectedErr: []error{errFlaky}},
+		{name: "canceled", ctx: canceled, err: errFlaky, expectedErr: []error{errFlaky}},
+		{name: "already says so", ctx: expired, err: context.DeadlineExceeded, expectedErr: []error{context.DeadlineExceeded}},
+		{name: "late", ctx: expired, err: errFlaky, expectedErr: []error{errFlaky, context.DeadlineExceeded}, wrapped: true},
+	}
+
+	for _, tc := range testCases {
+		mainTest.Run(tc.name, func(tt *testing.T) {
+			err := TimedOut(tc.ctx, "Get", time.Second, tc.err)
+			if len(tc.expectedErr) == 0 && err != nil {
+				tt.Errorf("wanted no error but got %v", err)
+			}
+
+			for _, v := range tc.expectedErr {
+				if !errors.Is(err, v) {
+					tt.Errorf("wanted %v but got %v", v, err)
+				}
+			}
+
+			var timeoutErr *TimeoutError
+			if got := errors.As(err, &timeoutErr); got != tc.wrapped {
+				tt.Errorf("wanted wrapped=%v but got %v", tc.wrapped, err)
+			}
+		})
+	}
+}
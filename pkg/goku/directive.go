@@ -0,0 +1,118 @@
+package goku
+
+import (
+	"go/ast"
+	"strings"
+)
+
+const directivePrefix = "//goku:"
+
+// A //goku: comment on a method (or one of its parameters) that tunes what
+// gets generated for it, e.g.
+//
+//	//goku:timeout 2s
+//	func (x *X) Method(ctx context.Context) error
+//
+// has Name "timeout" and Args "2s". Directives written on the same line as a
+// parameter get that parameter's name as their Args
+type Directive struct {
+	Name string
+	Args string
+}
+
+// Space separated arguments of the directive
+func (d Directive) Fields() []string { return strings.Fields(d.Args) }
+
+// Arguments of the directive written as key=value. Arguments without an
+// equals sign map to an empty string
+func (d Directive) Options() map[string]string {
+	opts := map[string]string{}
+	for _, v := range d.Fields() {
+		k, val, _ := strings.Cut(v, "=")
+		opts[k] = val
+	}
+	return opts
+}
+
+// Find the first directive on the method named name
+func (m MethodInfo) Directive(name string) (Directive, bool) {
+	for _, v := range m.Directives {
+		if v.Name == name {
+			return v, true
+		}
+	}
+
+	return Directive{}, false
+}
+
+// Whether the method has a directive named name
+func (m MethodInfo) HasDirective(name string) bool {
+	_, ok := m.Directive(name)
+	return ok
+}
+
+func parseDirective(text string) (Directive, bool) {
+	rest, ok := strings.CutPrefix(text, directivePrefix)
+	if !ok {
+		return Directive{}, false
+	}
+
+	name, args, _ := strings.Cut(strings.TrimSpace(rest), " ")
+	if name == "" {
+		return Directive{}, false
+	}
+
+	return Directive{Name: name, Args: strings.TrimSpace(args)}, true
+}
+
+// directives collects the directives in the doc comment of funcDecl and the
+// ones trailing its parameters. args are the parameters as read by signature
+func (p *pkgReaper) directives(funcDecl *ast.FuncDecl, args []TypeInfo) []Directive {
+	var directives []Directive
+	if funcDecl.Doc != nil {
+		for _, c := range funcDecl.Doc.List {
+			if d, ok := parseDirective(c.Text); ok {
+				directives = append(directives, d)
+			}
+		}
+	}
+
+	if p.file == nil || p.fset == nil {
+		return directives
+	}
+
+	params := funcDecl.Type.Params
+	for _, group := range p.file.Comments {
+		if group.Pos() < params.Opening || group.End() > params.Closing {
+			continue
+		}
+
+		for _, c := range group.List {
+			d, ok := parseDirective(c.Text)
+			if !ok {
+				continue
+			}
+
+			if name := p.paramOnLine(params, args, p.fset.Position(c.Pos()).Line); name != "" {
+				d.Args = name
+				directives = append(directives, d)
+			}
+		}
+	}
+
+	return directives
+}
+
+// paramOnLine finds the name of the last parameter that ends on line
+func (p *pkgReaper) paramOnLine(params *ast.FieldList, args []TypeInfo, line int) string {
+	name, idx := "", 0
+	for _, field := range params.List {
+		n := max(len(field.Names), 1)
+		if p.fset.Position(field.End()).Line == line && idx+n <= len(args) {
+			name = args[idx+n-1].Name
+		}
+		idx += n
+	}
+
+	return name
+}
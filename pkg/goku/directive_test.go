@@ -0,0 +1,49 @@
+package goku
+
+import (
+	"maps"
+	"slices"
+	"testing"
+)
+
+const directives = `package x
+type X struct{}
+
+// L does things
+//goku:timeout 2s
+//goku:cache ttl=30s size=10
+func (x X) L(
+	a int,
+	b string, //goku:redact
+) {}
+`
+
+func TestDirectives(t *testing.T) {
+	i := NewStructInfoGen("X")
+	if err := i.AddSrc(directives); err != nil {
+		t.Fatalf("src code is not valid in test %s", err)
+	}
+
+	s, err := i.StructInfo()
+	if err != nil {
+		t.Fatalf("should not err %s", err)
+	}
+
+	want := []Directive{{"timeout", "2s"}, {"cache", "ttl=30s size=10"}, {"redact", "b"}}
+	if got := s.Methods[0].Directives; !slices.Equal(want, got) {
+		t.Errorf("wanted %v but got %v", want, got)
+	}
+
+	cache, ok := s.Methods[0].Directive("cache")
+	if !ok {
+		t.Fatalf("should find cache directive")
+	}
+
+	if want, got := map[string]string{"ttl": "30s", "size": "10"}, cache.Options(); !maps.Equal(want, got) {
+		t.Errorf("wanted %v but got %v", want, got)
+	}
+
+	if s.Methods[0].HasDirective("read") {
+		t.Errorf("should not find a directive that isn't there")
+	}
+}
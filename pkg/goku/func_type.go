@@ -0,0 +1,97 @@
+package goku
+
+import (
+	"bytes"
+	"go/format"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type FakeOpt func(*fake)
+
+type fake struct {
+	PkgName    string
+	Imports    []Import
+	Target     string
+	Name       string
+	TypeParams string
+	TypeArgs   string
+
+	Tuple     string
+	Signature string
+	Record    string
+	Call      string
+
+	Arguments   []TypeInfo
+	Results     []TypeInfo
+	ResultsType string
+}
+
+// Override the package name of the generated fake
+func OverrideFakePkg(s string) FakeOpt { return func(f *fake) { f.PkgName = s } }
+
+// Generate a recording fake for the function type. Its Call method matches
+// the function's signature, so the method value can be passed anywhere the
+// function type is expected
+func (f FuncContract) GenFake(name string, opts ...FakeOpt) ([]byte, error) {
+	sig := &f.Signature
+	names := resultNames(sig)
+
+	x := fake{
+		PkgName:     f.PkgName,
+		Imports:     withImports(f.Imports, Import{Path: mockrtPath}),
+		Target:      f.TypeName,
+		Name:        name,
+		Tuple:       tuple(sig),
+		Signature:   params(sig) + results(sig, names),
+		Record:      strconv.Quote(f.TypeName),
+		ResultsType: lowerName(name) + "Results",
+	}
+
+	for _, v := range opts {
+		v(&x)
+	}
+
+	if len(f.TypeParams) > 0 {
+		x.TypeParams = typeParamList(f.TypeParams)
+		x.TypeArgs = typeArgList(f.TypeParams)
+	}
+
+	call := make([]string, len(sig.Arguments))
+	for idx, v := range sig.Arguments {
+		call[idx] = v.Name
+		x.Record += ", " + v.Name
+		x.Arguments = append(x.Arguments, TypeInfo{
+			Name: exportedName(v.Name),
+			Type: strings.Replace(v.Type, "...", "[]", 1),
+		})
+
+		if strings.HasPrefix(v.Type, "...") {
+			call[idx] += "..."
+		}
+	}
+	x.Call = strings.Join(call, ", ")
+
+	for idx, v := range sig.Returns {
+		x.Results = append(x.Results, TypeInfo{Name: names[idx], Type: v})
+	}
+
+	if len(x.Results) > 0 {
+		x.Imports = withImports(x.Imports, Import{Path: "sync"})
+	}
+
+	var b bytes.Buffer
+	if err := tmpls.ExecuteTemplate(&b, "fake.go.tmpl", x); err != nil {
+		return nil, err
+	}
+
+	return format.Source(b.Bytes())
+}
+
+// lowerName lowercases the first letter of name so it can be used unexported
+func lowerName(name string) string {
+	r, size := utf8.DecodeRuneInString(name)
+	return string(unicode.ToLower(r)) + name[size:]
+}
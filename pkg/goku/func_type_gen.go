@@ -0,0 +1,103 @@
+package goku
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+)
+
+// FuncTypeGen reads source code for a named function type, e.g.
+//
+//	type Retrier func(ctx context.Context, attempt int) error
+//
+// so fakes can be generated for it
+type FuncTypeGen struct {
+	nodelist
+	target string
+}
+
+// Create a new func type info object capable of creating fakes
+func NewFuncTypeGen(target string) *FuncTypeGen {
+	return &FuncTypeGen{
+		target:   target,
+		nodelist: nodelist{fset: token.NewFileSet()},
+	}
+}
+
+// Everything needed to generate code for a named function type
+type FuncContract struct {
+	PkgName    string
+	Imports    []Import
+	TypeName   string
+	TypeParams []TypeInfo
+	// The function's signature; Name is the type name and there's no receiver
+	Signature MethodInfo
+}
+
+// Generate func type info from the added source files
+func (i *FuncTypeGen) FuncContract() (*FuncContract, error) {
+	if len(i.nodes) == 0 {
+		return nil, ErrNoNodes
+	}
+
+	info := &FuncContract{
+		PkgName:    i.pkg,
+		TypeName:   i.target,
+		TypeParams: []TypeInfo{},
+	}
+
+	reaper := pkgReaper{
+		importAliases: map[string]Import{},
+		usedAliases:   map[string]struct{}{},
+		target:        i.target,
+	}
+
+	found := false
+	for _, node := range i.nodes {
+		reaper.addImports(node)
+
+		for _, decl := range node.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.TYPE {
+				continue
+			}
+
+			funcType := reaper.findFuncType(genDecl)
+			if funcType == nil {
+				continue
+			}
+
+			found = true
+			info.TypeParams = append(info.TypeParams, reaper.descendGenDecl(genDecl)...)
+			info.Signature.Name = i.target
+			info.Signature.Arguments, info.Signature.Returns = reaper.signature(funcType)
+		}
+	}
+
+	if !found {
+		return nil, fmt.Errorf("no function type named %s found in package %s", i.target, i.pkg)
+	}
+
+	imports, err := reaper.imports()
+	if err != nil {
+		return nil, err
+	}
+	info.Imports = imports
+
+	return info, nil
+}
+
+func (p *pkgReaper) findFuncType(genDecl *ast.GenDecl) *ast.FuncType {
+	for _, spec := range genDecl.Specs {
+		typeSpec, ok := spec.(*ast.TypeSpec)
+		if !ok || typeSpec.Name.Name != p.target {
+			continue
+		}
+
+		if funcType, ok := typeSpec.Type.(*ast.FuncType); ok {
+			return funcType
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package goku
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestGenFake(t *testing.T) {
+	for i := range 1 {
+		arg, err := files.ReadFile(fmt.Sprintf("testdata/gen_fake/%d-arg.go", i))
+		if err != nil {
+			t.Fatalf("test file unreadable %s", err)
+		}
+
+		want, err := files.ReadFile(fmt.Sprintf("testdata/gen_fake/%d-expected.txt", i))
+		if err != nil {
+			t.Fatalf("test file unreadable %s", err)
+		}
+
+		f := NewFuncTypeGen("Retrier")
+		if err = f.AddSrc(string(arg)); err != nil {
+			t.Errorf("should not err on generating source %s", err)
+			return
+		}
+
+		x, err := f.FuncContract()
+		if err != nil {
+			t.Errorf("should not err on func contract %s", err)
+			continue
+		}
+
+		b, err := x.GenFake("FakeRetrier")
+		if err != nil {
+			t.Errorf("should not err on gen fake test %s", err)
+			continue
+		}
+
+		got := strings.Split(strings.TrimSpace(string(b)), "\n")
+		wanted := strings.Split(strings.TrimSpace(string(want)), "\n")
+
+		if x, y := len(got), len(wanted); x != y {
+			t.Errorf("failed due to line count %d != %d, want\n%s\ngot\n%s", x, y, want, strings.Join(got, "\n"))
+			return
+		}
+
+		for i, v := range wanted {
+			if strings.TrimSpace(v) == strings.TrimSpace(got[i]) {
+				continue
+			}
+
+			t.Errorf("failed at line %d:\n-%s\n+%s", i+1, v, got[i])
+		}
+	}
+}
+
+func TestFuncContractNotFound(t *testing.T) {
+	f := NewFuncTypeGen("Missing")
+	if err := f.AddSrc("package x\ntype Other func()\n"); err != nil {
+		t.Fatalf("src code is not valid in test %s", err)
+	}
+
+	if _, err := f.FuncContract(); err == nil || err.Error() != "no function type named Missing found in package x" {
+		t.Errorf("wanted not found error, got %v", err)
+	}
+}
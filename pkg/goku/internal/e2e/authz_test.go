@@ -0,0 +1,47 @@
+package e2e
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/AnthonyHewins/goku/pkg/goku/decorrt"
+)
+
+type principalKey struct{}
+
+func principal(ctx context.Context) (decorrt.Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(decorrt.Principal)
+	return p, ok
+}
+
+func TestAuthz(t *testing.T) {
+	store := NewAuthzStoreInterface(NewStore(), decorrt.Authorizer{Principal: principal, DefaultAllow: true})
+
+	if err := store.Save(context.Background(), User{ID: 1}); !errors.Is(err, decorrt.ErrForbidden) {
+		t.Errorf("Save needs admin, got %v", err)
+	}
+
+	admin := context.WithValue(context.Background(), principalKey{}, decorrt.Roles{"admin"})
+	if err := store.Save(admin, User{ID: 1}); err != nil {
+		t.Errorf("admins can save, got %v", err)
+	}
+
+	if _, err := store.Get(context.Background(), 1); err != nil {
+		t.Errorf("Get is allowed by default, got %v", err)
+	}
+
+	want := "METHOD  POLICY\nGet     allow (default)\nSave    role=admin\nCount   exempt (noauthz)\nTouch   exempt (noauthz)\nExists  exempt (noauthz)\n"
+	if got := store.Report(); got != want {
+		t.Errorf("wanted report\n%s\ngot\n%s", want, got)
+	}
+
+	deny := NewAuthzStoreInterface(NewStore(), decorrt.Authorizer{Principal: principal})
+	if _, err := deny.Get(admin, 1); !errors.Is(err, decorrt.ErrForbidden) {
+		t.Errorf("Get is denied by default, got %v", err)
+	}
+
+	if n := deny.Count(); n != 0 {
+		t.Errorf("methods marked noauthz aren't checked, wanted 0 users but got %d", n)
+	}
+}
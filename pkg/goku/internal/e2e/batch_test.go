@@ -0,0 +1,45 @@
+package e2e
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestBatch(t *testing.T) {
+	next := NewClient()
+	for i := range 5 {
+		next.Put(context.Background(), fmt.Sprint(i), fmt.Sprint("value ", i))
+	}
+	next.calls = 0
+
+	client := NewBatchingClientInterface(next)
+
+	var wg sync.WaitGroup
+	values, errs := make([]string, 5), make([]error, 5)
+	for i := range values {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			values[i], errs[i] = client.Fetch(context.Background(), fmt.Sprint(i))
+		}()
+	}
+	wg.Wait()
+
+	for i, v := range values {
+		if want := fmt.Sprint("value ", i); v != want || errs[i] != nil {
+			t.Errorf("wanted %q, got %q, %v", want, v, errs[i])
+		}
+	}
+
+	if next.calls != 1 {
+		t.Errorf("concurrent fetches should be one call to FetchMany, got %d calls", next.calls)
+	}
+
+	next.Err = errFlaky
+	if _, err := client.Fetch(context.Background(), "0"); !errors.Is(err, errFlaky) {
+		t.Errorf("wanted FetchMany's error, got %v", err)
+	}
+}
@@ -0,0 +1,56 @@
+package e2e
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/AnthonyHewins/goku/pkg/goku/decorrt"
+)
+
+func TestBreaker(mainTest *testing.T) {
+	cfg := decorrt.BreakerConfig{FailureThreshold: 2, CoolDown: time.Hour}
+
+	mainTest.Run("per method", func(t *testing.T) {
+		next := NewClient()
+		next.Err = errFlaky
+		client := NewBreakerClientInterfacePerMethod(next, cfg)
+
+		for range 2 {
+			if _, err := client.Fetch(context.Background(), "k"); !errors.Is(err, errFlaky) {
+				t.Fatalf("wanted %v while closed, got %v", errFlaky, err)
+			}
+		}
+
+		if _, err := client.Fetch(context.Background(), "k"); !errors.Is(err, decorrt.ErrCircuitOpen) {
+			t.Errorf("wanted %v once open, got %v", decorrt.ErrCircuitOpen, err)
+		}
+
+		if next.calls != 2 {
+			t.Errorf("an open breaker shouldn't call through, got %d calls", next.calls)
+		}
+
+		next.Err = nil
+		if err := client.Put(context.Background(), "k", "v"); err != nil {
+			t.Errorf("Put has its own breaker and should go through, got %v", err)
+		}
+	})
+
+	mainTest.Run("shared", func(t *testing.T) {
+		next := NewClient()
+		next.Err = errFlaky
+		client := NewBreakerClientInterface(next, decorrt.NewBreaker(cfg))
+
+		client.Fetch(context.Background(), "k")
+		client.Put(context.Background(), "k", "v")
+
+		if err := client.Put(context.Background(), "k", "v"); !errors.Is(err, decorrt.ErrCircuitOpen) {
+			t.Errorf("wanted %v once open, got %v", decorrt.ErrCircuitOpen, err)
+		}
+
+		if got := client.Breaker("Fetch").State(); got != decorrt.BreakerOpen {
+			t.Errorf("the shared breaker should be open for Fetch too, got %s", got)
+		}
+	})
+}
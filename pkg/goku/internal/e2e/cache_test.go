@@ -0,0 +1,57 @@
+package e2e
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// counting counts calls to Get
+type counting struct {
+	StoreInterface
+	calls int
+}
+
+func (c *counting) Get(ctx context.Context, id int) (User, error) {
+	c.calls++
+	return c.StoreInterface.Get(ctx, id)
+}
+
+func TestCache(t *testing.T) {
+	next := &counting{StoreInterface: NewStore(User{ID: 1, Name: "bob"}, User{ID: 2}, User{ID: 3})}
+	store := NewCachingStoreInterface(next)
+
+	for range 3 {
+		if u, err := store.Get(context.Background(), 1); err != nil || u.Name != "bob" {
+			t.Fatalf("wanted bob, got %+v, %v", u, err)
+		}
+	}
+
+	if next.calls != 1 {
+		t.Errorf("repeat calls should be cached, got %d calls", next.calls)
+	}
+
+	for range 2 {
+		if _, err := store.Get(context.Background(), 4); !errors.Is(err, ErrNotFound) {
+			t.Errorf("wanted %v, got %v", ErrNotFound, err)
+		}
+	}
+
+	if next.calls != 3 {
+		t.Errorf("errors shouldn't be cached, got %d calls", next.calls)
+	}
+
+	// 1 is evicted once 2 and 3 fill the cache
+	store.Get(context.Background(), 2)
+	store.Get(context.Background(), 3)
+	store.Get(context.Background(), 1)
+	if next.calls != 6 {
+		t.Errorf("least recently used results should be evicted, got %d calls", next.calls)
+	}
+
+	store.Purge()
+	store.Get(context.Background(), 1)
+	if next.calls != 7 {
+		t.Errorf("purged results shouldn't be cached, got %d calls", next.calls)
+	}
+}
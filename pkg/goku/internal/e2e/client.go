@@ -0,0 +1,57 @@
+package e2e
+
+import "context"
+
+//go:generate goku iface Client -o gen_client_iface.go
+//go:generate goku decorate Client --breaker -o gen_client_breaker.go
+//go:generate goku decorate Client --batch -o gen_client_batch.go
+
+// Client talks to a remote key value store, so every call can fail
+type Client struct {
+	// Err is returned by every call when it's set
+	Err error
+
+	data  map[string]string
+	calls int
+}
+
+func NewClient() *Client { return &Client{data: map[string]string{}} }
+
+//goku:batch FetchMany wait=5ms
+func (c *Client) Fetch(ctx context.Context, key string) (string, error) {
+	c.calls++
+	if c.Err != nil {
+		return "", c.Err
+	}
+
+	v, ok := c.data[key]
+	if !ok {
+		return "", ErrNotFound
+	}
+	return v, nil
+}
+
+// FetchMany fetches every key at once, with an empty value for keys that
+// aren't set
+func (c *Client) FetchMany(ctx context.Context, keys []string) ([]string, error) {
+	c.calls++
+	if c.Err != nil {
+		return nil, c.Err
+	}
+
+	values := make([]string, len(keys))
+	for i, k := range keys {
+		values[i] = c.data[k]
+	}
+	return values, nil
+}
+
+func (c *Client) Put(ctx context.Context, key, value string) error {
+	c.calls++
+	if c.Err != nil {
+		return c.Err
+	}
+
+	c.data[key] = value
+	return nil
+}
@@ -0,0 +1,44 @@
+package e2e
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestFallback(mainTest *testing.T) {
+	cache, primary := NewStore(User{ID: 1, Name: "cached"}), NewStore(User{ID: 1}, User{ID: 2, Name: "alice"})
+
+	mainTest.Run("falls through", func(t *testing.T) {
+		store := NewFallbackStoreInterface(nil, cache, primary)
+		for id, want := range map[int]string{1: "cached", 2: "alice"} {
+			if u, err := store.Get(context.Background(), id); err != nil || u.Name != want {
+				t.Errorf("wanted %s, got %+v, %v", want, u, err)
+			}
+		}
+
+		if _, err := store.Get(context.Background(), 3); !errors.Is(err, ErrNotFound) {
+			t.Errorf("wanted %v when every store fails, got %v", ErrNotFound, err)
+		}
+
+		if got := store.Count(); got != 1 {
+			t.Errorf("methods that can't fail should only use the first store, got %d", got)
+		}
+	})
+
+	mainTest.Run("classified", func(t *testing.T) {
+		store := NewFallbackStoreInterface(func(err error) bool { return !errors.Is(err, ErrNotFound) }, cache, primary)
+		if _, err := store.Get(context.Background(), 2); !errors.Is(err, ErrNotFound) {
+			t.Errorf("not found shouldn't fall through, got %v", err)
+		}
+	})
+
+	mainTest.Run("no implementations", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("should panic without any implementation to call")
+			}
+		}()
+		NewFallbackStoreInterface(nil)
+	})
+}
@@ -0,0 +1,41 @@
+package e2e
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/AnthonyHewins/goku/pkg/goku/decorrt"
+)
+
+func TestFaults(t *testing.T) {
+	faults := decorrt.NewFaults(1,
+		decorrt.FaultRule{Method: "Get", Calls: []int{2}, Err: errFlaky},
+		decorrt.FaultRule{Method: "Count", Calls: []int{1}, Latency: 5 * time.Millisecond, Err: errFlaky},
+		decorrt.FaultRule{Method: "Touch", Panic: "touched"},
+	)
+	store := NewFaultyStoreInterface(NewStore(User{ID: 1, Name: "bob"}), faults)
+
+	for i, want := range []error{nil, errFlaky, nil} {
+		if _, err := store.Get(context.Background(), 1); !errors.Is(err, want) {
+			t.Errorf("call %d: wanted %v, got %v", i+1, want, err)
+		}
+	}
+
+	start := time.Now()
+	if n := store.Count(); n != 1 {
+		t.Errorf("Count can't fail, so it should call through, got %d", n)
+	}
+
+	if since := time.Since(start); since < 5*time.Millisecond {
+		t.Errorf("Count should've been delayed, took %s", since)
+	}
+
+	defer func() {
+		if v := recover(); v != "touched" {
+			t.Errorf("wanted Touch to panic, got %v", v)
+		}
+	}()
+	store.Touch(time.Now())
+}
@@ -0,0 +1,71 @@
+// Code generated by goku; DO NOT EDIT
+// Version: unknown
+// Command: goku decorate Store --authz -o gen_authz.go
+package e2e
+
+import (
+	"context"
+	"github.com/AnthonyHewins/goku/pkg/goku/decorrt"
+	"time"
+)
+
+// force the decorator to implement the interface
+var _ StoreInterface = (*AuthzStoreInterface)(nil)
+
+// AuthzStoreInterfacePolicy is the roles allowed to call each method of StoreInterface.
+// Methods without any are allowed or denied by default, unless they're
+// exempt with //goku:noauthz
+var AuthzStoreInterfacePolicy = []decorrt.MethodPolicy{
+	{Method: "Get"},
+	{Method: "Save", Roles: []string{"admin"}},
+	{Method: "Count", Exempt: true},
+	{Method: "Touch", Exempt: true},
+	{Method: "Exists", Exempt: true},
+}
+
+// AuthzStoreInterface checks callers of StoreInterface are allowed to make each call
+type AuthzStoreInterface struct {
+	next  StoreInterface
+	authz decorrt.Authorizer
+}
+
+// NewAuthzStoreInterface only lets calls authz allows through to next, following
+// AuthzStoreInterfacePolicy
+func NewAuthzStoreInterface(next StoreInterface, authz decorrt.Authorizer) *AuthzStoreInterface {
+	return &AuthzStoreInterface{next: next, authz: authz}
+}
+
+// Report lists who can call each method
+func (decorator *AuthzStoreInterface) Report() string {
+	return decorrt.PolicyReport(AuthzStoreInterfacePolicy, decorator.authz.DefaultAllow)
+}
+
+func (decorator *AuthzStoreInterface) Get(ctx context.Context, id int) (r0 User, r1 error) {
+	if err := decorator.authz.Check(ctx, "Get"); err != nil {
+		r1 = err
+		return
+	}
+
+	return decorator.next.Get(ctx, id)
+}
+
+func (decorator *AuthzStoreInterface) Save(ctx context.Context, u User) (r0 error) {
+	if err := decorator.authz.Check(ctx, "Save", "admin"); err != nil {
+		r0 = err
+		return
+	}
+
+	return decorator.next.Save(ctx, u)
+}
+
+func (decorator *AuthzStoreInterface) Count() (r0 int) {
+	return decorator.next.Count()
+}
+
+func (decorator *AuthzStoreInterface) Touch(t time.Time) {
+	decorator.next.Touch(t)
+}
+
+func (decorator *AuthzStoreInterface) Exists(ctx context.Context, id int) (r0 bool) {
+	return decorator.next.Exists(ctx, id)
+}
@@ -0,0 +1,72 @@
+// Code generated by goku; DO NOT EDIT
+// Version: unknown
+// Command: goku decorate Store --cache -o gen_cache.go
+package e2e
+
+import (
+	"context"
+	"github.com/AnthonyHewins/goku/pkg/goku/decorrt"
+	"time"
+)
+
+// force the decorator to implement the interface
+var _ StoreInterface = (*CachingStoreInterface)(nil)
+
+// cachingStoreInterfaceGetKey identifies a call to Get in its cache
+type cachingStoreInterfaceGetKey struct {
+	id int
+}
+
+// cachingStoreInterfaceGetValue is what a call to Get returned
+type cachingStoreInterfaceGetValue struct {
+	r0 User
+}
+
+// CachingStoreInterface caches the results of calls to StoreInterface that succeed
+type CachingStoreInterface struct {
+	next     StoreInterface
+	cacheGet *decorrt.Cache[cachingStoreInterfaceGetKey, cachingStoreInterfaceGetValue]
+}
+
+// NewCachingStoreInterface caches the results of next's methods that have a
+// //goku:cache directive, for as long as it says
+func NewCachingStoreInterface(next StoreInterface) *CachingStoreInterface {
+	return &CachingStoreInterface{
+		next:     next,
+		cacheGet: decorrt.NewCache[cachingStoreInterfaceGetKey, cachingStoreInterfaceGetValue](time.Minute, 2),
+	}
+}
+
+// Purge every cached result
+func (decorator *CachingStoreInterface) Purge() {
+	decorator.cacheGet.Purge()
+}
+
+func (decorator *CachingStoreInterface) Get(ctx context.Context, id int) (r0 User, r1 error) {
+	key := cachingStoreInterfaceGetKey{id: id}
+	if entry, ok := decorator.cacheGet.Get(key); ok {
+		return entry.r0, nil
+	}
+
+	r0, r1 = decorator.next.Get(ctx, id)
+	if r1 == nil {
+		decorator.cacheGet.Set(key, cachingStoreInterfaceGetValue{r0: r0})
+	}
+	return
+}
+
+func (decorator *CachingStoreInterface) Save(ctx context.Context, u User) (r0 error) {
+	return decorator.next.Save(ctx, u)
+}
+
+func (decorator *CachingStoreInterface) Count() (r0 int) {
+	return decorator.next.Count()
+}
+
+func (decorator *CachingStoreInterface) Touch(t time.Time) {
+	decorator.next.Touch(t)
+}
+
+func (decorator *CachingStoreInterface) Exists(ctx context.Context, id int) (r0 bool) {
+	return decorator.next.Exists(ctx, id)
+}
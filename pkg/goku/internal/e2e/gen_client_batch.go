@@ -0,0 +1,44 @@
+// Code generated by goku; DO NOT EDIT
+// Version: unknown
+// Command: goku decorate Client --batch -o gen_client_batch.go
+package e2e
+
+import (
+	"context"
+	"github.com/AnthonyHewins/goku/pkg/goku/decorrt"
+	"time"
+)
+
+// force the decorator to implement the interface
+var _ ClientInterface = (*BatchingClientInterface)(nil)
+
+// BatchingClientInterface groups concurrent calls to ClientInterface that load one thing
+// into calls that load many
+type BatchingClientInterface struct {
+	next       ClientInterface
+	batchFetch *decorrt.Batcher[string, string]
+}
+
+// NewBatchingClientInterface batches calls to next's methods that have a //goku:batch
+// directive, as it says
+func NewBatchingClientInterface(next ClientInterface) *BatchingClientInterface {
+	return &BatchingClientInterface{
+		next: next,
+		batchFetch: decorrt.NewBatcher(
+			decorrt.BatchConfig{Wait: 5 * time.Millisecond, MaxSize: 0},
+			next.FetchMany,
+		),
+	}
+}
+
+func (decorator *BatchingClientInterface) Fetch(ctx context.Context, key string) (r0 string, r1 error) {
+	return decorator.batchFetch.Load(ctx, key)
+}
+
+func (decorator *BatchingClientInterface) FetchMany(ctx context.Context, keys []string) (r0 []string, r1 error) {
+	return decorator.next.FetchMany(ctx, keys)
+}
+
+func (decorator *BatchingClientInterface) Put(ctx context.Context, key string, value string) (r0 error) {
+	return decorator.next.Put(ctx, key, value)
+}
@@ -0,0 +1,74 @@
+// Code generated by goku; DO NOT EDIT
+// Version: unknown
+// Command: goku decorate Client --breaker -o gen_client_breaker.go
+package e2e
+
+import (
+	"context"
+	"github.com/AnthonyHewins/goku/pkg/goku/decorrt"
+)
+
+// force the decorator to implement the interface
+var _ ClientInterface = (*BreakerClientInterface)(nil)
+
+// BreakerClientInterface guards calls to ClientInterface with circuit breakers, failing
+// fast with decorrt.ErrCircuitOpen while they're open
+type BreakerClientInterface struct {
+	next     ClientInterface
+	breakers map[string]*decorrt.Breaker
+}
+
+// NewBreakerClientInterface guards every method of next with the same breaker, so
+// failures of any of them open it for all of them
+func NewBreakerClientInterface(next ClientInterface, breaker *decorrt.Breaker) *BreakerClientInterface {
+	return &BreakerClientInterface{
+		next: next,
+		breakers: map[string]*decorrt.Breaker{
+			"Fetch":     breaker,
+			"FetchMany": breaker,
+			"Put":       breaker,
+		},
+	}
+}
+
+// NewBreakerClientInterfacePerMethod guards each method of next with its own breaker
+// configured by cfg
+func NewBreakerClientInterfacePerMethod(next ClientInterface, cfg decorrt.BreakerConfig) *BreakerClientInterface {
+	return &BreakerClientInterface{
+		next: next,
+		breakers: map[string]*decorrt.Breaker{
+			"Fetch":     decorrt.NewBreaker(cfg),
+			"FetchMany": decorrt.NewBreaker(cfg),
+			"Put":       decorrt.NewBreaker(cfg),
+		},
+	}
+}
+
+// Breaker guarding method, or nil if there's no such method
+func (decorator *BreakerClientInterface) Breaker(method string) *decorrt.Breaker {
+	return decorator.breakers[method]
+}
+
+func (decorator *BreakerClientInterface) Fetch(ctx context.Context, key string) (r0 string, r1 error) {
+	r1 = decorator.breakers["Fetch"].Do(func() error {
+		r0, r1 = decorator.next.Fetch(ctx, key)
+		return r1
+	})
+	return
+}
+
+func (decorator *BreakerClientInterface) FetchMany(ctx context.Context, keys []string) (r0 []string, r1 error) {
+	r1 = decorator.breakers["FetchMany"].Do(func() error {
+		r0, r1 = decorator.next.FetchMany(ctx, keys)
+		return r1
+	})
+	return
+}
+
+func (decorator *BreakerClientInterface) Put(ctx context.Context, key string, value string) (r0 error) {
+	r0 = decorator.breakers["Put"].Do(func() error {
+		r0 = decorator.next.Put(ctx, key, value)
+		return r0
+	})
+	return
+}
@@ -0,0 +1,17 @@
+// Code generated by goku; DO NOT EDIT
+// Version: unknown
+// Command: goku iface Client -o gen_client_iface.go
+package e2e
+
+import (
+	"context"
+)
+
+// force the underlying to implement the interface
+var _ = ClientInterface(&Client{})
+
+type ClientInterface interface {
+	Fetch(ctx context.Context, key string) (string, error)
+	FetchMany(ctx context.Context, keys []string) ([]string, error)
+	Put(ctx context.Context, key string, value string) error
+}
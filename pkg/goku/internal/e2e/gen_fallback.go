@@ -0,0 +1,60 @@
+// Code generated by goku; DO NOT EDIT
+// Version: unknown
+// Command: goku compose Store --fallback -o gen_fallback.go
+package e2e
+
+import (
+	"context"
+	"github.com/AnthonyHewins/goku/pkg/goku/decorrt"
+	"time"
+)
+
+// force the decorator to implement the interface
+var _ StoreInterface = (*FallbackStoreInterface)(nil)
+
+// FallbackStoreInterface tries a list of implementations of StoreInterface in order until
+// one succeeds
+type FallbackStoreInterface struct {
+	impls    []StoreInterface
+	classify func(error) bool
+}
+
+// NewFallbackStoreInterface tries each of impls in order. An error only falls through to
+// the next one if classify returns true for it; if it's nil, every error
+// does. Methods that can't return an error only call the first of impls, so
+// it panics without any
+func NewFallbackStoreInterface(classify func(error) bool, impls ...StoreInterface) *FallbackStoreInterface {
+	if len(impls) == 0 {
+		panic("NewFallbackStoreInterface needs at least one implementation")
+	}
+
+	return &FallbackStoreInterface{impls: impls, classify: classify}
+}
+
+func (composite *FallbackStoreInterface) Get(ctx context.Context, id int) (r0 User, r1 error) {
+	r1 = decorrt.Fallback(len(composite.impls), composite.classify, func(i int) error {
+		r0, r1 = composite.impls[i].Get(ctx, id)
+		return r1
+	})
+	return
+}
+
+func (composite *FallbackStoreInterface) Save(ctx context.Context, u User) (r0 error) {
+	r0 = decorrt.Fallback(len(composite.impls), composite.classify, func(i int) error {
+		r0 = composite.impls[i].Save(ctx, u)
+		return r0
+	})
+	return
+}
+
+func (composite *FallbackStoreInterface) Count() (r0 int) {
+	return composite.impls[0].Count()
+}
+
+func (composite *FallbackStoreInterface) Touch(t time.Time) {
+	composite.impls[0].Touch(t)
+}
+
+func (composite *FallbackStoreInterface) Exists(ctx context.Context, id int) (r0 bool) {
+	return composite.impls[0].Exists(ctx, id)
+}
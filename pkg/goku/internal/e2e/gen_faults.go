@@ -0,0 +1,61 @@
+// Code generated by goku; DO NOT EDIT
+// Version: unknown
+// Command: goku decorate Store --faults -o gen_faults.go
+package e2e
+
+import (
+	"context"
+	"github.com/AnthonyHewins/goku/pkg/goku/decorrt"
+	"time"
+)
+
+// force the decorator to implement the interface
+var _ StoreInterface = (*FaultyStoreInterface)(nil)
+
+// FaultyStoreInterface injects faults into calls to StoreInterface
+type FaultyStoreInterface struct {
+	next   StoreInterface
+	faults *decorrt.Faults
+}
+
+// NewFaultyStoreInterface injects faults into calls to next as the rules of faults
+// pick. Calls without a fault go through to next
+func NewFaultyStoreInterface(next StoreInterface, faults *decorrt.Faults) *FaultyStoreInterface {
+	return &FaultyStoreInterface{next: next, faults: faults}
+}
+
+func (decorator *FaultyStoreInterface) Get(ctx context.Context, id int) (r0 User, r1 error) {
+	if err := decorator.faults.Inject(ctx, "Get"); err != nil {
+		r1 = err
+		return
+	}
+
+	return decorator.next.Get(ctx, id)
+}
+
+func (decorator *FaultyStoreInterface) Save(ctx context.Context, u User) (r0 error) {
+	if err := decorator.faults.Inject(ctx, "Save"); err != nil {
+		r0 = err
+		return
+	}
+
+	return decorator.next.Save(ctx, u)
+}
+
+func (decorator *FaultyStoreInterface) Count() (r0 int) {
+	decorator.faults.Inject(context.Background(), "Count")
+
+	return decorator.next.Count()
+}
+
+func (decorator *FaultyStoreInterface) Touch(t time.Time) {
+	decorator.faults.Inject(context.Background(), "Touch")
+
+	decorator.next.Touch(t)
+}
+
+func (decorator *FaultyStoreInterface) Exists(ctx context.Context, id int) (r0 bool) {
+	decorator.faults.Inject(ctx, "Exists")
+
+	return decorator.next.Exists(ctx, id)
+}
@@ -0,0 +1,183 @@
+// Code generated by goku; DO NOT EDIT
+// Version: unknown
+// Command: goku decorate Store --hooks -o gen_hooks.go
+package e2e
+
+import (
+	"context"
+	"time"
+)
+
+// force the decorator to implement the interface
+var _ StoreInterface = (*HookedStoreInterface)(nil)
+
+// HookedStoreInterfaceGetFunc is the signature of StoreInterface.Get
+type HookedStoreInterfaceGetFunc func(ctx context.Context, id int) (User, error)
+
+// HookedStoreInterfaceSaveFunc is the signature of StoreInterface.Save
+type HookedStoreInterfaceSaveFunc func(ctx context.Context, u User) error
+
+// HookedStoreInterfaceCountFunc is the signature of StoreInterface.Count
+type HookedStoreInterfaceCountFunc func() int
+
+// HookedStoreInterfaceTouchFunc is the signature of StoreInterface.Touch
+type HookedStoreInterfaceTouchFunc func(t time.Time)
+
+// HookedStoreInterfaceExistsFunc is the signature of StoreInterface.Exists
+type HookedStoreInterfaceExistsFunc func(ctx context.Context, id int) bool
+
+// HookedStoreInterface calls hooks around every call to StoreInterface
+type HookedStoreInterface struct {
+	next StoreInterface
+
+	// Called before every call with the method's name and arguments,
+	// excluding a leading context
+	Before []func(method string, args []any)
+	// Called after every call with the method's name, results and how long
+	// the call took
+	After []func(method string, results []any, dur time.Duration)
+
+	// Interceptors wrap calls to their method if they're set. Each is passed
+	// the next implementation in the chain, and can change its arguments and
+	// results, or not call it at all
+	InterceptGet    func(HookedStoreInterfaceGetFunc) HookedStoreInterfaceGetFunc
+	InterceptSave   func(HookedStoreInterfaceSaveFunc) HookedStoreInterfaceSaveFunc
+	InterceptCount  func(HookedStoreInterfaceCountFunc) HookedStoreInterfaceCountFunc
+	InterceptTouch  func(HookedStoreInterfaceTouchFunc) HookedStoreInterfaceTouchFunc
+	InterceptExists func(HookedStoreInterfaceExistsFunc) HookedStoreInterfaceExistsFunc
+}
+
+// NewHookedStoreInterface calls hooks around calls to next. Add them and any
+// interceptors before it's used
+func NewHookedStoreInterface(next StoreInterface) *HookedStoreInterface {
+	return &HookedStoreInterface{next: next}
+}
+
+func (decorator *HookedStoreInterface) Get(ctx context.Context, id int) (r0 User, r1 error) {
+	if len(decorator.Before) > 0 {
+		args := []any{id}
+		for _, hook := range decorator.Before {
+			hook("Get", args)
+		}
+	}
+
+	call := HookedStoreInterfaceGetFunc(decorator.next.Get)
+	if decorator.InterceptGet != nil {
+		call = decorator.InterceptGet(call)
+	}
+
+	start := time.Now()
+	r0, r1 = call(ctx, id)
+	dur := time.Since(start)
+
+	if len(decorator.After) > 0 {
+		results := []any{r0, r1}
+		for _, hook := range decorator.After {
+			hook("Get", results, dur)
+		}
+	}
+	return
+}
+
+func (decorator *HookedStoreInterface) Save(ctx context.Context, u User) (r0 error) {
+	if len(decorator.Before) > 0 {
+		args := []any{u}
+		for _, hook := range decorator.Before {
+			hook("Save", args)
+		}
+	}
+
+	call := HookedStoreInterfaceSaveFunc(decorator.next.Save)
+	if decorator.InterceptSave != nil {
+		call = decorator.InterceptSave(call)
+	}
+
+	start := time.Now()
+	r0 = call(ctx, u)
+	dur := time.Since(start)
+
+	if len(decorator.After) > 0 {
+		results := []any{r0}
+		for _, hook := range decorator.After {
+			hook("Save", results, dur)
+		}
+	}
+	return
+}
+
+func (decorator *HookedStoreInterface) Count() (r0 int) {
+	if len(decorator.Before) > 0 {
+		args := []any{}
+		for _, hook := range decorator.Before {
+			hook("Count", args)
+		}
+	}
+
+	call := HookedStoreInterfaceCountFunc(decorator.next.Count)
+	if decorator.InterceptCount != nil {
+		call = decorator.InterceptCount(call)
+	}
+
+	start := time.Now()
+	r0 = call()
+	dur := time.Since(start)
+
+	if len(decorator.After) > 0 {
+		results := []any{r0}
+		for _, hook := range decorator.After {
+			hook("Count", results, dur)
+		}
+	}
+	return
+}
+
+func (decorator *HookedStoreInterface) Touch(t time.Time) {
+	if len(decorator.Before) > 0 {
+		args := []any{t}
+		for _, hook := range decorator.Before {
+			hook("Touch", args)
+		}
+	}
+
+	call := HookedStoreInterfaceTouchFunc(decorator.next.Touch)
+	if decorator.InterceptTouch != nil {
+		call = decorator.InterceptTouch(call)
+	}
+
+	start := time.Now()
+	call(t)
+	dur := time.Since(start)
+
+	if len(decorator.After) > 0 {
+		results := []any{}
+		for _, hook := range decorator.After {
+			hook("Touch", results, dur)
+		}
+	}
+}
+
+func (decorator *HookedStoreInterface) Exists(ctx context.Context, id int) (r0 bool) {
+	if len(decorator.Before) > 0 {
+		args := []any{id}
+		for _, hook := range decorator.Before {
+			hook("Exists", args)
+		}
+	}
+
+	call := HookedStoreInterfaceExistsFunc(decorator.next.Exists)
+	if decorator.InterceptExists != nil {
+		call = decorator.InterceptExists(call)
+	}
+
+	start := time.Now()
+	r0 = call(ctx, id)
+	dur := time.Since(start)
+
+	if len(decorator.After) > 0 {
+		results := []any{r0}
+		for _, hook := range decorator.After {
+			hook("Exists", results, dur)
+		}
+	}
+	return
+}
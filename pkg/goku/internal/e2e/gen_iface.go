@@ -0,0 +1,20 @@
+// Code generated by goku; DO NOT EDIT
+// Version: unknown
+// Command: goku iface Store -m MockStore --mock-out gen_mock_test.go -o gen_iface.go
+package e2e
+
+import (
+	"context"
+	"time"
+)
+
+// force the underlying to implement the interface
+var _ = StoreInterface(&Store{})
+
+type StoreInterface interface {
+	Get(ctx context.Context, id int) (User, error)
+	Save(ctx context.Context, u User) error
+	Count() int
+	Touch(t time.Time)
+	Exists(ctx context.Context, id int) bool
+}
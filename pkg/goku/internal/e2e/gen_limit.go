@@ -0,0 +1,97 @@
+// Code generated by goku; DO NOT EDIT
+// Version: unknown
+// Command: goku decorate Store --limit -o gen_limit.go
+package e2e
+
+import (
+	"context"
+	"github.com/AnthonyHewins/goku/pkg/goku/decorrt"
+	"time"
+)
+
+// force the decorator to implement the interface
+var _ StoreInterface = (*LimitedStoreInterface)(nil)
+
+// LimitedStoreInterface limits how often and how many calls to StoreInterface are made
+type LimitedStoreInterface struct {
+	next     StoreInterface
+	limiters map[string]*decorrt.Limiter
+}
+
+// NewLimitedStoreInterface limits calls to every method of next together with the
+// same limiter
+func NewLimitedStoreInterface(next StoreInterface, limiter *decorrt.Limiter) *LimitedStoreInterface {
+	return &LimitedStoreInterface{
+		next: next,
+		limiters: map[string]*decorrt.Limiter{
+			"Get":    limiter,
+			"Save":   limiter,
+			"Count":  limiter,
+			"Touch":  limiter,
+			"Exists": limiter,
+		},
+	}
+}
+
+// NewLimitedStoreInterfacePerMethod limits calls to each method of next separately,
+// with limiters configured by cfg
+func NewLimitedStoreInterfacePerMethod(next StoreInterface, cfg decorrt.LimitConfig) *LimitedStoreInterface {
+	return &LimitedStoreInterface{
+		next: next,
+		limiters: map[string]*decorrt.Limiter{
+			"Get":    decorrt.NewLimiter(cfg),
+			"Save":   decorrt.NewLimiter(cfg),
+			"Count":  decorrt.NewLimiter(cfg),
+			"Touch":  decorrt.NewLimiter(cfg),
+			"Exists": decorrt.NewLimiter(cfg),
+		},
+	}
+}
+
+// Limiter of method, or nil if there's no such method
+func (decorator *LimitedStoreInterface) Limiter(method string) *decorrt.Limiter {
+	return decorator.limiters[method]
+}
+
+func (decorator *LimitedStoreInterface) Get(ctx context.Context, id int) (r0 User, r1 error) {
+	r1 = decorator.limiters["Get"].Do(ctx, func() error {
+		r0, r1 = decorator.next.Get(ctx, id)
+		return r1
+	})
+	return
+}
+
+func (decorator *LimitedStoreInterface) Save(ctx context.Context, u User) (r0 error) {
+	r0 = decorator.limiters["Save"].Do(ctx, func() error {
+		r0 = decorator.next.Save(ctx, u)
+		return r0
+	})
+	return
+}
+
+func (decorator *LimitedStoreInterface) Count() (r0 int) {
+	decorator.limiters["Count"].Do(context.Background(), func() error {
+		r0 = decorator.next.Count()
+		return nil
+	})
+	return
+}
+
+func (decorator *LimitedStoreInterface) Touch(t time.Time) {
+	decorator.limiters["Touch"].Do(context.Background(), func() error {
+		decorator.next.Touch(t)
+		return nil
+	})
+}
+
+func (decorator *LimitedStoreInterface) Exists(ctx context.Context, id int) (r0 bool) {
+	if ctx.Err() != nil {
+		return
+	}
+
+	decorator.limiters["Exists"].Do(ctx, func() error {
+		r0 = decorator.next.Exists(ctx, id)
+		return nil
+	})
+	return
+}
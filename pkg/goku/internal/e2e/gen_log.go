@@ -0,0 +1,120 @@
+// Code generated by goku; DO NOT EDIT
+// Version: unknown
+// Command: goku decorate Store --log -o gen_log.go
+package e2e
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// force the decorator to implement the interface
+var _ StoreInterface = (*LoggingStoreInterface)(nil)
+
+// LoggingStoreInterface logs every call to StoreInterface through a *slog.Logger
+type LoggingStoreInterface struct {
+	next   StoreInterface
+	logger *slog.Logger
+
+	// Level calls that don't fail are logged at. Failures are logged at slog.LevelError
+	Level slog.Level
+	// Whether to attach arguments and results to each entry
+	LogArgs, LogResults bool
+}
+
+// NewLoggingStoreInterface logs calls to next at slog.LevelInfo with their arguments and results
+func NewLoggingStoreInterface(next StoreInterface, logger *slog.Logger) *LoggingStoreInterface {
+	return &LoggingStoreInterface{
+		next:       next,
+		logger:     logger,
+		Level:      slog.LevelInfo,
+		LogArgs:    true,
+		LogResults: true,
+	}
+}
+
+func (decorator *LoggingStoreInterface) Get(ctx context.Context, id int) (r0 User, r1 error) {
+	start := time.Now()
+	r0, r1 = decorator.next.Get(ctx, id)
+
+	attrs := []slog.Attr{slog.String("method", "Get"), slog.Duration("duration", time.Since(start))}
+	if decorator.LogArgs {
+		attrs = append(attrs, slog.Group("args", slog.Any("id", id)))
+	}
+
+	level := decorator.Level
+	if r1 != nil {
+		level = slog.LevelError
+		attrs = append(attrs, slog.Any("error", r1))
+	}
+
+	decorator.logger.LogAttrs(ctx, level, "Get", attrs...)
+	return
+}
+
+func (decorator *LoggingStoreInterface) Save(ctx context.Context, u User) (r0 error) {
+	start := time.Now()
+	r0 = decorator.next.Save(ctx, u)
+
+	attrs := []slog.Attr{slog.String("method", "Save"), slog.Duration("duration", time.Since(start))}
+	if decorator.LogArgs {
+		attrs = append(attrs, slog.Group("args", slog.String("u", "[REDACTED]")))
+	}
+
+	level := decorator.Level
+	if r0 != nil {
+		level = slog.LevelError
+		attrs = append(attrs, slog.Any("error", r0))
+	}
+
+	decorator.logger.LogAttrs(ctx, level, "Save", attrs...)
+	return
+}
+
+func (decorator *LoggingStoreInterface) Count() (r0 int) {
+	start := time.Now()
+	r0 = decorator.next.Count()
+
+	attrs := []slog.Attr{slog.String("method", "Count"), slog.Duration("duration", time.Since(start))}
+	if decorator.LogResults {
+		attrs = append(attrs, slog.Group("results", slog.Any("r0", r0)))
+	}
+
+	level := decorator.Level
+
+	decorator.logger.LogAttrs(context.Background(), level, "Count", attrs...)
+	return
+}
+
+func (decorator *LoggingStoreInterface) Touch(t time.Time) {
+	start := time.Now()
+	decorator.next.Touch(t)
+
+	attrs := []slog.Attr{slog.String("method", "Touch"), slog.Duration("duration", time.Since(start))}
+	if decorator.LogArgs {
+		attrs = append(attrs, slog.Group("args", slog.Any("t", t)))
+	}
+
+	level := decorator.Level
+
+	decorator.logger.LogAttrs(context.Background(), level, "Touch", attrs...)
+}
+
+func (decorator *LoggingStoreInterface) Exists(ctx context.Context, id int) (r0 bool) {
+	start := time.Now()
+	r0 = decorator.next.Exists(ctx, id)
+
+	attrs := []slog.Attr{slog.String("method", "Exists"), slog.Duration("duration", time.Since(start))}
+	if decorator.LogArgs {
+		attrs = append(attrs, slog.Group("args", slog.Any("id", id)))
+	}
+	if decorator.LogResults {
+		attrs = append(attrs, slog.Group("results", slog.Any("r0", r0)))
+	}
+
+	level := decorator.Level
+
+	decorator.logger.LogAttrs(ctx, level, "Exists", attrs...)
+	return
+}
@@ -0,0 +1,93 @@
+// Code generated by goku; DO NOT EDIT
+// Version: unknown
+// Command: goku decorate Store --metrics -o gen_metrics.go
+package e2e
+
+import (
+	"context"
+	"time"
+)
+
+// force the decorator to implement the interface
+var _ StoreInterface = (*InstrumentedStoreInterface)(nil)
+
+// Names of the methods of StoreInterface, as they're passed to StoreInterfaceMetrics
+const (
+	StoreInterfaceMethodGet    = "Get"
+	StoreInterfaceMethodSave   = "Save"
+	StoreInterfaceMethodCount  = "Count"
+	StoreInterfaceMethodTouch  = "Touch"
+	StoreInterfaceMethodExists = "Exists"
+)
+
+// StoreInterfaceMetrics receives the measurements InstrumentedStoreInterface takes. Implement it
+// over whichever metrics backend you use
+type StoreInterfaceMetrics interface {
+	// Called once for every call to method
+	IncCalls(method string)
+	// Called once for every call to method that returns a non-nil error
+	IncErrors(method string)
+	// Called with how long every call to method took
+	ObserveLatency(method string, d time.Duration)
+}
+
+// InstrumentedStoreInterface records call counts, error counts and latencies of every call to StoreInterface
+type InstrumentedStoreInterface struct {
+	next    StoreInterface
+	metrics StoreInterfaceMetrics
+}
+
+// NewInstrumentedStoreInterface records calls to next in metrics
+func NewInstrumentedStoreInterface(next StoreInterface, metrics StoreInterfaceMetrics) *InstrumentedStoreInterface {
+	return &InstrumentedStoreInterface{next: next, metrics: metrics}
+}
+
+func (decorator *InstrumentedStoreInterface) Get(ctx context.Context, id int) (r0 User, r1 error) {
+	start := time.Now()
+	r0, r1 = decorator.next.Get(ctx, id)
+
+	decorator.metrics.ObserveLatency(StoreInterfaceMethodGet, time.Since(start))
+	decorator.metrics.IncCalls(StoreInterfaceMethodGet)
+	if r1 != nil {
+		decorator.metrics.IncErrors(StoreInterfaceMethodGet)
+	}
+	return
+}
+
+func (decorator *InstrumentedStoreInterface) Save(ctx context.Context, u User) (r0 error) {
+	start := time.Now()
+	r0 = decorator.next.Save(ctx, u)
+
+	decorator.metrics.ObserveLatency(StoreInterfaceMethodSave, time.Since(start))
+	decorator.metrics.IncCalls(StoreInterfaceMethodSave)
+	if r0 != nil {
+		decorator.metrics.IncErrors(StoreInterfaceMethodSave)
+	}
+	return
+}
+
+func (decorator *InstrumentedStoreInterface) Count() (r0 int) {
+	start := time.Now()
+	r0 = decorator.next.Count()
+
+	decorator.metrics.ObserveLatency(StoreInterfaceMethodCount, time.Since(start))
+	decorator.metrics.IncCalls(StoreInterfaceMethodCount)
+	return
+}
+
+func (decorator *InstrumentedStoreInterface) Touch(t time.Time) {
+	start := time.Now()
+	decorator.next.Touch(t)
+
+	decorator.metrics.ObserveLatency(StoreInterfaceMethodTouch, time.Since(start))
+	decorator.metrics.IncCalls(StoreInterfaceMethodTouch)
+}
+
+func (decorator *InstrumentedStoreInterface) Exists(ctx context.Context, id int) (r0 bool) {
+	start := time.Now()
+	r0 = decorator.next.Exists(ctx, id)
+
+	decorator.metrics.ObserveLatency(StoreInterfaceMethodExists, time.Since(start))
+	decorator.metrics.IncCalls(StoreInterfaceMethodExists)
+	return
+}
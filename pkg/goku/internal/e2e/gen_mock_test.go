@@ -0,0 +1,256 @@
+// Code generated by goku; DO NOT EDIT
+// Version: unknown
+// Command: goku iface Store -m MockStore --mock-out gen_mock_test.go -o gen_iface.go
+package e2e
+
+import (
+	"context"
+	"github.com/AnthonyHewins/goku/pkg/goku/mockrt"
+	"testing"
+	"time"
+)
+
+// force the mock to implement the interface
+var _ = StoreInterface(&MockStore{})
+
+type MockStore struct {
+	mockrt.Recorder
+	GetFn    func(ctx context.Context, id int) (User, error)
+	SaveFn   func(ctx context.Context, u User) error
+	CountFn  func() int
+	TouchFn  func(t time.Time)
+	ExistsFn func(ctx context.Context, id int) bool
+}
+
+func (mockImplementation *MockStore) Get(ctx context.Context, id int) (r0 User, r1 error) {
+	mockImplementation.Recorder.Record("Get", ctx, id)
+	if r1 = mockImplementation.Recorder.Await(ctx, "Get"); r1 != nil {
+		return
+	}
+	if mockImplementation.GetFn == nil {
+		mockImplementation.Recorder.Unexpected("Get", ctx, id)
+		return
+	}
+	return mockImplementation.GetFn(ctx, id)
+}
+
+func (mockImplementation *MockStore) AssertGetCalled(t testing.TB, ctx, id any) bool {
+	t.Helper()
+	return mockImplementation.Recorder.AssertCalled(t, "Get", ctx, id)
+}
+
+func (mockImplementation *MockStore) AssertGetCalledTimes(t testing.TB, n int) bool {
+	t.Helper()
+	return mockImplementation.Recorder.AssertCalledTimes(t, "Get", n)
+}
+
+func (mockImplementation *MockStore) AssertGetNotCalled(t testing.TB) bool {
+	t.Helper()
+	return mockImplementation.Recorder.AssertNotCalled(t, "Get")
+}
+
+// MockStoreGetCall holds the arguments of a call to Get
+type MockStoreGetCall struct {
+	Ctx context.Context
+	Id  int
+}
+
+func (mockImplementation *MockStore) WaitForGet(ctx context.Context) (MockStoreGetCall, error) {
+	c, err := mockImplementation.Recorder.WaitFor(ctx, "Get")
+	if err != nil {
+		return MockStoreGetCall{}, err
+	}
+
+	var call MockStoreGetCall
+	call.Ctx, _ = c.Args[0].(context.Context)
+	call.Id, _ = c.Args[1].(int)
+	return call, nil
+}
+
+func (mockImplementation *MockStore) GetCalled() <-chan mockrt.Call {
+	return mockImplementation.Recorder.Called("Get")
+}
+
+func (mockImplementation *MockStore) Save(ctx context.Context, u User) (r0 error) {
+	mockImplementation.Recorder.Record("Save", ctx, u)
+	if r0 = mockImplementation.Recorder.Await(ctx, "Save"); r0 != nil {
+		return
+	}
+	if mockImplementation.SaveFn == nil {
+		mockImplementation.Recorder.Unexpected("Save", ctx, u)
+		return
+	}
+	return mockImplementation.SaveFn(ctx, u)
+}
+
+func (mockImplementation *MockStore) AssertSaveCalled(t testing.TB, ctx, u any) bool {
+	t.Helper()
+	return mockImplementation.Recorder.AssertCalled(t, "Save", ctx, u)
+}
+
+func (mockImplementation *MockStore) AssertSaveCalledTimes(t testing.TB, n int) bool {
+	t.Helper()
+	return mockImplementation.Recorder.AssertCalledTimes(t, "Save", n)
+}
+
+func (mockImplementation *MockStore) AssertSaveNotCalled(t testing.TB) bool {
+	t.Helper()
+	return mockImplementation.Recorder.AssertNotCalled(t, "Save")
+}
+
+// MockStoreSaveCall holds the arguments of a call to Save
+type MockStoreSaveCall struct {
+	Ctx context.Context
+	U   User
+}
+
+func (mockImplementation *MockStore) WaitForSave(ctx context.Context) (MockStoreSaveCall, error) {
+	c, err := mockImplementation.Recorder.WaitFor(ctx, "Save")
+	if err != nil {
+		return MockStoreSaveCall{}, err
+	}
+
+	var call MockStoreSaveCall
+	call.Ctx, _ = c.Args[0].(context.Context)
+	call.U, _ = c.Args[1].(User)
+	return call, nil
+}
+
+func (mockImplementation *MockStore) SaveCalled() <-chan mockrt.Call {
+	return mockImplementation.Recorder.Called("Save")
+}
+
+func (mockImplementation *MockStore) Count() (r0 int) {
+	mockImplementation.Recorder.Record("Count")
+	if mockImplementation.CountFn == nil {
+		mockImplementation.Recorder.Unexpected("Count")
+		return
+	}
+	return mockImplementation.CountFn()
+}
+
+func (mockImplementation *MockStore) AssertCountCalled(t testing.TB) bool {
+	t.Helper()
+	return mockImplementation.Recorder.AssertCalled(t, "Count")
+}
+
+func (mockImplementation *MockStore) AssertCountCalledTimes(t testing.TB, n int) bool {
+	t.Helper()
+	return mockImplementation.Recorder.AssertCalledTimes(t, "Count", n)
+}
+
+func (mockImplementation *MockStore) AssertCountNotCalled(t testing.TB) bool {
+	t.Helper()
+	return mockImplementation.Recorder.AssertNotCalled(t, "Count")
+}
+
+// MockStoreCountCall holds the arguments of a call to Count
+type MockStoreCountCall struct {
+}
+
+func (mockImplementation *MockStore) WaitForCount(ctx context.Context) (MockStoreCountCall, error) {
+	_, err := mockImplementation.Recorder.WaitFor(ctx, "Count")
+	if err != nil {
+		return MockStoreCountCall{}, err
+	}
+
+	var call MockStoreCountCall
+	return call, nil
+}
+
+func (mockImplementation *MockStore) CountCalled() <-chan mockrt.Call {
+	return mockImplementation.Recorder.Called("Count")
+}
+
+func (mockImplementation *MockStore) Touch(t time.Time) {
+	mockImplementation.Recorder.Record("Touch", t)
+	if mockImplementation.TouchFn == nil {
+		mockImplementation.Recorder.Unexpected("Touch", t)
+		return
+	}
+	mockImplementation.TouchFn(t)
+}
+
+func (mockImplementation *MockStore) AssertTouchCalled(_t testing.TB, t any) bool {
+	_t.Helper()
+	return mockImplementation.Recorder.AssertCalled(_t, "Touch", t)
+}
+
+func (mockImplementation *MockStore) AssertTouchCalledTimes(_t testing.TB, n int) bool {
+	_t.Helper()
+	return mockImplementation.Recorder.AssertCalledTimes(_t, "Touch", n)
+}
+
+func (mockImplementation *MockStore) AssertTouchNotCalled(_t testing.TB) bool {
+	_t.Helper()
+	return mockImplementation.Recorder.AssertNotCalled(_t, "Touch")
+}
+
+// MockStoreTouchCall holds the arguments of a call to Touch
+type MockStoreTouchCall struct {
+	T time.Time
+}
+
+func (mockImplementation *MockStore) WaitForTouch(ctx context.Context) (MockStoreTouchCall, error) {
+	c, err := mockImplementation.Recorder.WaitFor(ctx, "Touch")
+	if err != nil {
+		return MockStoreTouchCall{}, err
+	}
+
+	var call MockStoreTouchCall
+	call.T, _ = c.Args[0].(time.Time)
+	return call, nil
+}
+
+func (mockImplementation *MockStore) TouchCalled() <-chan mockrt.Call {
+	return mockImplementation.Recorder.Called("Touch")
+}
+
+func (mockImplementation *MockStore) Exists(ctx context.Context, id int) (r0 bool) {
+	mockImplementation.Recorder.Record("Exists", ctx, id)
+	if mockImplementation.Recorder.Await(ctx, "Exists") != nil {
+		return
+	}
+	if mockImplementation.ExistsFn == nil {
+		mockImplementation.Recorder.Unexpected("Exists", ctx, id)
+		return
+	}
+	return mockImplementation.ExistsFn(ctx, id)
+}
+
+func (mockImplementation *MockStore) AssertExistsCalled(t testing.TB, ctx, id any) bool {
+	t.Helper()
+	return mockImplementation.Recorder.AssertCalled(t, "Exists", ctx, id)
+}
+
+func (mockImplementation *MockStore) AssertExistsCalledTimes(t testing.TB, n int) bool {
+	t.Helper()
+	return mockImplementation.Recorder.AssertCalledTimes(t, "Exists", n)
+}
+
+func (mockImplementation *MockStore) AssertExistsNotCalled(t testing.TB) bool {
+	t.Helper()
+	return mockImplementation.Recorder.AssertNotCalled(t, "Exists")
+}
+
+// MockStoreExistsCall holds the arguments of a call to Exists
+type MockStoreExistsCall struct {
+	Ctx context.Context
+	Id  int
+}
+
+func (mockImplementation *MockStore) WaitForExists(ctx context.Context) (MockStoreExistsCall, error) {
+	c, err := mockImplementation.Recorder.WaitFor(ctx, "Exists")
+	if err != nil {
+		return MockStoreExistsCall{}, err
+	}
+
+	var call MockStoreExistsCall
+	call.Ctx, _ = c.Args[0].(context.Context)
+	call.Id, _ = c.Args[1].(int)
+	return call, nil
+}
+
+func (mockImplementation *MockStore) ExistsCalled() <-chan mockrt.Call {
+	return mockImplementation.Recorder.Called("Exists")
+}
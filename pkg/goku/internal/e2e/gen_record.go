@@ -0,0 +1,54 @@
+// Code generated by goku; DO NOT EDIT
+// Version: unknown
+// Command: goku decorate Store --record -o gen_record.go
+package e2e
+
+import (
+	"context"
+	"github.com/AnthonyHewins/goku/pkg/goku/decorrt"
+	"time"
+)
+
+// force the decorator to implement the interface
+var _ StoreInterface = (*RecordingStoreInterface)(nil)
+
+// RecordingStoreInterface records every call to StoreInterface onto a cassette
+type RecordingStoreInterface struct {
+	next     StoreInterface
+	cassette *decorrt.Cassette
+}
+
+// NewRecordingStoreInterface records calls to next onto cassette. Save it once
+// everything's been recorded
+func NewRecordingStoreInterface(next StoreInterface, cassette *decorrt.Cassette) *RecordingStoreInterface {
+	return &RecordingStoreInterface{next: next, cassette: cassette}
+}
+
+func (decorator *RecordingStoreInterface) Get(ctx context.Context, id int) (r0 User, r1 error) {
+	r0, r1 = decorator.next.Get(ctx, id)
+	decorator.cassette.Record("Get", []any{id}, []any{r0}, r1)
+	return
+}
+
+func (decorator *RecordingStoreInterface) Save(ctx context.Context, u User) (r0 error) {
+	r0 = decorator.next.Save(ctx, u)
+	decorator.cassette.Record("Save", []any{u}, nil, r0)
+	return
+}
+
+func (decorator *RecordingStoreInterface) Count() (r0 int) {
+	r0 = decorator.next.Count()
+	decorator.cassette.Record("Count", nil, []any{r0}, nil)
+	return
+}
+
+func (decorator *RecordingStoreInterface) Touch(t time.Time) {
+	decorator.next.Touch(t)
+	decorator.cassette.Record("Touch", []any{t}, nil, nil)
+}
+
+func (decorator *RecordingStoreInterface) Exists(ctx context.Context, id int) (r0 bool) {
+	r0 = decorator.next.Exists(ctx, id)
+	decorator.cassette.Record("Exists", []any{id}, []any{r0}, nil)
+	return
+}
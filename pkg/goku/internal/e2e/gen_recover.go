@@ -0,0 +1,76 @@
+// Code generated by goku; DO NOT EDIT
+// Version: unknown
+// Command: goku decorate Store --recover -o gen_recover.go
+package e2e
+
+import (
+	"context"
+	"github.com/AnthonyHewins/goku/pkg/goku/decorrt"
+	"time"
+)
+
+// force the decorator to implement the interface
+var _ StoreInterface = (*RecoveringStoreInterface)(nil)
+
+// RecoveringStoreInterface recovers panics from calls to StoreInterface
+type RecoveringStoreInterface struct {
+	next    StoreInterface
+	handler func(*decorrt.PanicError)
+}
+
+// NewRecoveringStoreInterface recovers panics from calls to next. Methods that return an
+// error return the panic as one, and panics from any other method are passed
+// to handler. If handler is nil, they're dropped
+func NewRecoveringStoreInterface(next StoreInterface, handler func(*decorrt.PanicError)) *RecoveringStoreInterface {
+	return &RecoveringStoreInterface{next: next, handler: handler}
+}
+
+func (decorator *RecoveringStoreInterface) Get(ctx context.Context, id int) (r0 User, r1 error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			r1 = decorrt.Recovered("Get", recovered)
+		}
+	}()
+
+	return decorator.next.Get(ctx, id)
+}
+
+func (decorator *RecoveringStoreInterface) Save(ctx context.Context, u User) (r0 error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			r0 = decorrt.Recovered("Save", recovered)
+		}
+	}()
+
+	return decorator.next.Save(ctx, u)
+}
+
+func (decorator *RecoveringStoreInterface) Count() (r0 int) {
+	defer func() {
+		if recovered := recover(); recovered != nil && decorator.handler != nil {
+			decorator.handler(decorrt.Recovered("Count", recovered))
+		}
+	}()
+
+	return decorator.next.Count()
+}
+
+func (decorator *RecoveringStoreInterface) Touch(t time.Time) {
+	defer func() {
+		if recovered := recover(); recovered != nil && decorator.handler != nil {
+			decorator.handler(decorrt.Recovered("Touch", recovered))
+		}
+	}()
+
+	decorator.next.Touch(t)
+}
+
+func (decorator *RecoveringStoreInterface) Exists(ctx context.Context, id int) (r0 bool) {
+	defer func() {
+		if recovered := recover(); recovered != nil && decorator.handler != nil {
+			decorator.handler(decorrt.Recovered("Exists", recovered))
+		}
+	}()
+
+	return decorator.next.Exists(ctx, id)
+}
@@ -0,0 +1,53 @@
+// Code generated by goku; DO NOT EDIT
+// Version: unknown
+// Command: goku decorate Store --replay -o gen_replay.go
+package e2e
+
+import (
+	"context"
+	"github.com/AnthonyHewins/goku/pkg/goku/decorrt"
+	"time"
+)
+
+// force the decorator to implement the interface
+var _ StoreInterface = (*ReplayingStoreInterface)(nil)
+
+// ReplayingStoreInterface implements StoreInterface by replaying calls recorded onto a cassette
+type ReplayingStoreInterface struct {
+	cassette *decorrt.Cassette
+}
+
+// NewReplayingStoreInterface serves calls from cassette, failing any it has no recording of
+func NewReplayingStoreInterface(cassette *decorrt.Cassette) *ReplayingStoreInterface {
+	return &ReplayingStoreInterface{cassette: cassette}
+}
+
+func (decorator *ReplayingStoreInterface) Get(ctx context.Context, id int) (r0 User, r1 error) {
+	r1 = decorator.cassette.Replay("Get", []any{id}, &r0)
+	return
+}
+
+func (decorator *ReplayingStoreInterface) Save(ctx context.Context, u User) (r0 error) {
+	r0 = decorator.cassette.Replay("Save", []any{u})
+	return
+}
+
+func (decorator *ReplayingStoreInterface) Count() (r0 int) {
+	if err := decorator.cassette.Replay("Count", nil, &r0); err != nil {
+		panic(err)
+	}
+	return
+}
+
+func (decorator *ReplayingStoreInterface) Touch(t time.Time) {
+	if err := decorator.cassette.Replay("Touch", []any{t}); err != nil {
+		panic(err)
+	}
+}
+
+func (decorator *ReplayingStoreInterface) Exists(ctx context.Context, id int) (r0 bool) {
+	if err := decorator.cassette.Replay("Exists", []any{id}, &r0); err != nil {
+		panic(err)
+	}
+	return
+}
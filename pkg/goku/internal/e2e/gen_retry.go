@@ -0,0 +1,48 @@
+// Code generated by goku; DO NOT EDIT
+// Version: unknown
+// Command: goku decorate Store --retry -o gen_retry.go
+package e2e
+
+import (
+	"context"
+	"github.com/AnthonyHewins/goku/pkg/goku/decorrt"
+	"time"
+)
+
+// force the decorator to implement the interface
+var _ StoreInterface = (*RetryingStoreInterface)(nil)
+
+// RetryingStoreInterface retries failed calls to StoreInterface
+type RetryingStoreInterface struct {
+	next   StoreInterface
+	policy decorrt.RetryPolicy
+}
+
+// NewRetryingStoreInterface retries calls to next that return an error as policy allows
+func NewRetryingStoreInterface(next StoreInterface, policy decorrt.RetryPolicy) *RetryingStoreInterface {
+	return &RetryingStoreInterface{next: next, policy: policy}
+}
+
+func (decorator *RetryingStoreInterface) Get(ctx context.Context, id int) (r0 User, r1 error) {
+	r1 = decorator.policy.Do(ctx, func() error {
+		r0, r1 = decorator.next.Get(ctx, id)
+		return r1
+	})
+	return
+}
+
+func (decorator *RetryingStoreInterface) Save(ctx context.Context, u User) (r0 error) {
+	return decorator.next.Save(ctx, u)
+}
+
+func (decorator *RetryingStoreInterface) Count() (r0 int) {
+	return decorator.next.Count()
+}
+
+func (decorator *RetryingStoreInterface) Touch(t time.Time) {
+	decorator.next.Touch(t)
+}
+
+func (decorator *RetryingStoreInterface) Exists(ctx context.Context, id int) (r0 bool) {
+	return decorator.next.Exists(ctx, id)
+}
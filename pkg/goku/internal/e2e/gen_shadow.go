@@ -0,0 +1,96 @@
+// Code generated by goku; DO NOT EDIT
+// Version: unknown
+// Command: goku compose Store --shadow -o gen_shadow.go
+package e2e
+
+import (
+	"context"
+	"github.com/AnthonyHewins/goku/pkg/goku/decorrt"
+	"reflect"
+	"time"
+)
+
+// force the decorator to implement the interface
+var _ StoreInterface = (*ShadowStoreInterface)(nil)
+
+// ShadowStoreInterface serves calls to StoreInterface from a primary implementation,
+// and checks a candidate gives the same results in the background
+type ShadowStoreInterface struct {
+	primary, candidate StoreInterface
+	shadow             *decorrt.Shadow
+}
+
+// NewShadowStoreInterface serves calls from primary, and reports calls candidate
+// doesn't answer the same way to report. It's called from background
+// goroutines, so it must be safe to call concurrently
+func NewShadowStoreInterface(primary, candidate StoreInterface, report func(decorrt.Mismatch)) *ShadowStoreInterface {
+	return &ShadowStoreInterface{primary: primary, candidate: candidate, shadow: decorrt.NewShadow(report)}
+}
+
+// Wait for calls to the candidate that are still running to finish
+func (composite *ShadowStoreInterface) Wait() { composite.shadow.Wait() }
+
+func (composite *ShadowStoreInterface) Get(ctx context.Context, id int) (r0 User, r1 error) {
+	r0, r1 = composite.primary.Get(ctx, id)
+	ctx = context.WithoutCancel(ctx)
+	composite.shadow.Go("Get", []any{id}, func() *decorrt.Mismatch {
+		c0, c1 := composite.candidate.Get(ctx, id)
+		if composite.equalGet(r0, r1, c0, c1) {
+			return nil
+		}
+		return &decorrt.Mismatch{Method: "Get", Args: []any{id}, Primary: []any{r0, r1}, Candidate: []any{c0, c1}}
+	})
+	return
+}
+
+// equalGet compares results of Get from the primary with the candidate's
+func (composite *ShadowStoreInterface) equalGet(p0 User, p1 error, c0 User, c1 error) bool {
+	return reflect.DeepEqual(p0, c0) && decorrt.SameError(p1, c1)
+}
+
+func (composite *ShadowStoreInterface) Save(ctx context.Context, u User) (r0 error) {
+	return composite.primary.Save(ctx, u)
+}
+
+func (composite *ShadowStoreInterface) Count() (r0 int) {
+	r0 = composite.primary.Count()
+	composite.shadow.Go("Count", nil, func() *decorrt.Mismatch {
+		c0 := composite.candidate.Count()
+		if composite.equalCount(r0, c0) {
+			return nil
+		}
+		return &decorrt.Mismatch{Method: "Count", Args: nil, Primary: []any{r0}, Candidate: []any{c0}}
+	})
+	return
+}
+
+// equalCount compares results of Count from the primary with the candidate's
+func (composite *ShadowStoreInterface) equalCount(p0 int, c0 int) bool {
+	return reflect.DeepEqual(p0, c0)
+}
+
+func (composite *ShadowStoreInterface) Touch(t time.Time) {
+	composite.primary.Touch(t)
+	composite.shadow.Go("Touch", []any{t}, func() *decorrt.Mismatch {
+		composite.candidate.Touch(t)
+		return nil
+	})
+}
+
+func (composite *ShadowStoreInterface) Exists(ctx context.Context, id int) (r0 bool) {
+	r0 = composite.primary.Exists(ctx, id)
+	ctx = context.WithoutCancel(ctx)
+	composite.shadow.Go("Exists", []any{id}, func() *decorrt.Mismatch {
+		c0 := composite.candidate.Exists(ctx, id)
+		if composite.equalExists(r0, c0) {
+			return nil
+		}
+		return &decorrt.Mismatch{Method: "Exists", Args: []any{id}, Primary: []any{r0}, Candidate: []any{c0}}
+	})
+	return
+}
+
+// equalExists compares results of Exists from the primary with the candidate's
+func (composite *ShadowStoreInterface) equalExists(p0 bool, c0 bool) bool {
+	return reflect.DeepEqual(p0, c0)
+}
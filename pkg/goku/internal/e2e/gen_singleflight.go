@@ -0,0 +1,84 @@
+// Code generated by goku; DO NOT EDIT
+// Version: unknown
+// Command: goku decorate Store --singleflight -o gen_singleflight.go
+package e2e
+
+import (
+	"context"
+	"github.com/AnthonyHewins/goku/pkg/goku/decorrt"
+	"golang.org/x/sync/singleflight"
+	"time"
+)
+
+// force the decorator to implement the interface
+var _ StoreInterface = (*SingleflightStoreInterface)(nil)
+
+// singleflightStoreInterfaceGetResult is what a call to Get returned, to share
+// between callers
+type singleflightStoreInterfaceGetResult struct {
+	r0 User
+}
+
+// singleflightStoreInterfaceExistsResult is what a call to Exists returned, to share
+// between callers
+type singleflightStoreInterfaceExistsResult struct {
+	r0 bool
+}
+
+// SingleflightStoreInterface collapses concurrent identical calls to StoreInterface into one
+type SingleflightStoreInterface struct {
+	next  StoreInterface
+	group singleflight.Group
+}
+
+// NewSingleflightStoreInterface shares the results of a call to next between every identical
+// call made while it's in flight, for methods with a //goku:singleflight
+// directive
+func NewSingleflightStoreInterface(next StoreInterface) *SingleflightStoreInterface {
+	return &SingleflightStoreInterface{next: next}
+}
+
+func (decorator *SingleflightStoreInterface) Get(ctx context.Context, id int) (r0 User, r1 error) {
+	flight := decorator.group.DoChan("Get("+decorrt.Key(id)+")", func() (any, error) {
+		ctx := context.WithoutCancel(ctx)
+		v0, vErr := decorator.next.Get(ctx, id)
+		return singleflightStoreInterfaceGetResult{r0: v0}, vErr
+	})
+
+	select {
+	case <-ctx.Done():
+		r1 = ctx.Err()
+		return
+	case res := <-flight:
+		entry := res.Val.(singleflightStoreInterfaceGetResult)
+		return entry.r0, res.Err
+	}
+}
+
+func (decorator *SingleflightStoreInterface) Save(ctx context.Context, u User) (r0 error) {
+	return decorator.next.Save(ctx, u)
+}
+
+func (decorator *SingleflightStoreInterface) Count() (r0 int) {
+	return decorator.next.Count()
+}
+
+func (decorator *SingleflightStoreInterface) Touch(t time.Time) {
+	decorator.next.Touch(t)
+}
+
+func (decorator *SingleflightStoreInterface) Exists(ctx context.Context, id int) (r0 bool) {
+	flight := decorator.group.DoChan("Exists("+decorrt.Key(id)+")", func() (any, error) {
+		ctx := context.WithoutCancel(ctx)
+		v0 := decorator.next.Exists(ctx, id)
+		return singleflightStoreInterfaceExistsResult{r0: v0}, nil
+	})
+
+	select {
+	case <-ctx.Done():
+		return
+	case res := <-flight:
+		entry := res.Val.(singleflightStoreInterfaceExistsResult)
+		return entry.r0
+	}
+}
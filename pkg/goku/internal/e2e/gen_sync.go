@@ -0,0 +1,60 @@
+// Code generated by goku; DO NOT EDIT
+// Version: unknown
+// Command: goku decorate Store --sync --rw -o gen_sync.go
+package e2e
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// force the decorator to implement the interface
+var _ StoreInterface = (*SynchronizedStoreInterface)(nil)
+
+// SynchronizedStoreInterface makes StoreInterface safe to share between goroutines by holding
+// a lock around every call
+type SynchronizedStoreInterface struct {
+	next StoreInterface
+	mu   sync.RWMutex
+}
+
+// NewSynchronizedStoreInterface guards every call to next
+func NewSynchronizedStoreInterface(next StoreInterface) *SynchronizedStoreInterface {
+	return &SynchronizedStoreInterface{next: next}
+}
+
+func (decorator *SynchronizedStoreInterface) Get(ctx context.Context, id int) (r0 User, r1 error) {
+	decorator.mu.RLock()
+	defer decorator.mu.RUnlock()
+
+	return decorator.next.Get(ctx, id)
+}
+
+func (decorator *SynchronizedStoreInterface) Save(ctx context.Context, u User) (r0 error) {
+	decorator.mu.Lock()
+	defer decorator.mu.Unlock()
+
+	return decorator.next.Save(ctx, u)
+}
+
+func (decorator *SynchronizedStoreInterface) Count() (r0 int) {
+	decorator.mu.RLock()
+	defer decorator.mu.RUnlock()
+
+	return decorator.next.Count()
+}
+
+func (decorator *SynchronizedStoreInterface) Touch(t time.Time) {
+	decorator.mu.Lock()
+	defer decorator.mu.Unlock()
+
+	decorator.next.Touch(t)
+}
+
+func (decorator *SynchronizedStoreInterface) Exists(ctx context.Context, id int) (r0 bool) {
+	decorator.mu.RLock()
+	defer decorator.mu.RUnlock()
+
+	return decorator.next.Exists(ctx, id)
+}
@@ -0,0 +1,72 @@
+// Code generated by goku; DO NOT EDIT
+// Version: unknown
+// Command: goku compose Store --tee -o gen_tee.go
+package e2e
+
+import (
+	"context"
+	"github.com/AnthonyHewins/goku/pkg/goku/decorrt"
+	"time"
+)
+
+// force the decorator to implement the interface
+var _ StoreInterface = (*TeeStoreInterface)(nil)
+
+// TeeStoreInterface calls every one of a list of implementations of StoreInterface
+// on each call. Results come from the first one
+type TeeStoreInterface struct {
+	impls  []StoreInterface
+	config decorrt.TeeConfig
+}
+
+// NewTeeStoreInterface calls each of impls on every call, as config says
+func NewTeeStoreInterface(config decorrt.TeeConfig, impls ...StoreInterface) *TeeStoreInterface {
+	return &TeeStoreInterface{impls: impls, config: config}
+}
+
+func (composite *TeeStoreInterface) Get(ctx context.Context, id int) (r0 User, r1 error) {
+	r1 = decorrt.Tee(composite.config, len(composite.impls), func(i int) error {
+		v0, v1 := composite.impls[i].Get(ctx, id)
+		if i == 0 {
+			r0 = v0
+		}
+		return v1
+	})
+	return
+}
+
+func (composite *TeeStoreInterface) Save(ctx context.Context, u User) (r0 error) {
+	r0 = decorrt.Tee(composite.config, len(composite.impls), func(i int) error {
+		return composite.impls[i].Save(ctx, u)
+	})
+	return
+}
+
+func (composite *TeeStoreInterface) Count() (r0 int) {
+	decorrt.Tee(composite.config, len(composite.impls), func(i int) error {
+		v0 := composite.impls[i].Count()
+		if i == 0 {
+			r0 = v0
+		}
+		return nil
+	})
+	return
+}
+
+func (composite *TeeStoreInterface) Touch(t time.Time) {
+	decorrt.Tee(composite.config, len(composite.impls), func(i int) error {
+		composite.impls[i].Touch(t)
+		return nil
+	})
+}
+
+func (composite *TeeStoreInterface) Exists(ctx context.Context, id int) (r0 bool) {
+	decorrt.Tee(composite.config, len(composite.impls), func(i int) error {
+		v0 := composite.impls[i].Exists(ctx, id)
+		if i == 0 {
+			r0 = v0
+		}
+		return nil
+	})
+	return
+}
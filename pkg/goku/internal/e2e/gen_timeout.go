@@ -0,0 +1,81 @@
+// Code generated by goku; DO NOT EDIT
+// Version: unknown
+// Command: goku decorate Store --timeout -o gen_timeout.go
+package e2e
+
+import (
+	"context"
+	"github.com/AnthonyHewins/goku/pkg/goku/decorrt"
+	"maps"
+	"time"
+)
+
+// force the decorator to implement the interface
+var _ StoreInterface = (*TimeoutStoreInterface)(nil)
+
+// TimeoutStoreInterface gives calls to StoreInterface that take a context a deadline
+type TimeoutStoreInterface struct {
+	next     StoreInterface
+	timeouts map[string]time.Duration
+}
+
+// NewTimeoutStoreInterface gives calls to next the deadline in timeouts for their
+// method, falling back to the one in its //goku:timeout directive. Methods
+// with neither have no deadline
+func NewTimeoutStoreInterface(next StoreInterface, timeouts map[string]time.Duration) *TimeoutStoreInterface {
+	defaults := map[string]time.Duration{
+		"Get": 10 * time.Millisecond,
+	}
+	maps.Copy(defaults, timeouts)
+
+	return &TimeoutStoreInterface{next: next, timeouts: defaults}
+}
+
+func (decorator *TimeoutStoreInterface) Get(ctx context.Context, id int) (r0 User, r1 error) {
+	timeout, ok := decorator.timeouts["Get"]
+	if !ok {
+		return decorator.next.Get(ctx, id)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	r0, r1 = decorator.next.Get(ctx, id)
+	r1 = decorrt.TimedOut(ctx, "Get", timeout, r1)
+	return
+}
+
+func (decorator *TimeoutStoreInterface) Save(ctx context.Context, u User) (r0 error) {
+	timeout, ok := decorator.timeouts["Save"]
+	if !ok {
+		return decorator.next.Save(ctx, u)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	r0 = decorator.next.Save(ctx, u)
+	r0 = decorrt.TimedOut(ctx, "Save", timeout, r0)
+	return
+}
+
+func (decorator *TimeoutStoreInterface) Count() (r0 int) {
+	return decorator.next.Count()
+}
+
+func (decorator *TimeoutStoreInterface) Touch(t time.Time) {
+	decorator.next.Touch(t)
+}
+
+func (decorator *TimeoutStoreInterface) Exists(ctx context.Context, id int) (r0 bool) {
+	timeout, ok := decorator.timeouts["Exists"]
+	if !ok {
+		return decorator.next.Exists(ctx, id)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	r0 = decorator.next.Exists(ctx, id)
+	return
+}
@@ -0,0 +1,86 @@
+// Code generated by goku; DO NOT EDIT
+// Version: unknown
+// Command: goku decorate Store --trace -o gen_trace.go
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"time"
+)
+
+// force the decorator to implement the interface
+var _ StoreInterface = (*TracingStoreInterface)(nil)
+
+// TracingStoreInterface starts an OpenTelemetry span around every call to StoreInterface
+type TracingStoreInterface struct {
+	next   StoreInterface
+	tracer trace.Tracer
+	base   context.Context
+}
+
+// NewTracingStoreInterface traces calls to next with tracer. Spans of methods that take
+// a context are children of it. Methods that don't are traced under base, or
+// not traced at all if base is nil
+func NewTracingStoreInterface(next StoreInterface, tracer trace.Tracer, base context.Context) *TracingStoreInterface {
+	return &TracingStoreInterface{next: next, tracer: tracer, base: base}
+}
+
+func (decorator *TracingStoreInterface) Get(ctx context.Context, id int) (r0 User, r1 error) {
+	ctx, span := decorator.tracer.Start(ctx, "StoreInterface.Get", trace.WithAttributes(attribute.Int("id", id)))
+	defer span.End()
+
+	r0, r1 = decorator.next.Get(ctx, id)
+	if r1 != nil {
+		span.RecordError(r1)
+		span.SetStatus(codes.Error, r1.Error())
+	}
+	return
+}
+
+func (decorator *TracingStoreInterface) Save(ctx context.Context, u User) (r0 error) {
+	ctx, span := decorator.tracer.Start(ctx, "StoreInterface.Save")
+	defer span.End()
+
+	r0 = decorator.next.Save(ctx, u)
+	if r0 != nil {
+		span.RecordError(r0)
+		span.SetStatus(codes.Error, r0.Error())
+	}
+	return
+}
+
+func (decorator *TracingStoreInterface) Count() (r0 int) {
+	if decorator.base == nil {
+		return decorator.next.Count()
+	}
+
+	_, span := decorator.tracer.Start(decorator.base, "StoreInterface.Count")
+	defer span.End()
+
+	r0 = decorator.next.Count()
+	return
+}
+
+func (decorator *TracingStoreInterface) Touch(t time.Time) {
+	if decorator.base == nil {
+		decorator.next.Touch(t)
+		return
+	}
+
+	_, span := decorator.tracer.Start(decorator.base, "StoreInterface.Touch", trace.WithAttributes(attribute.String("t", fmt.Sprint(t))))
+	defer span.End()
+
+	decorator.next.Touch(t)
+}
+
+func (decorator *TracingStoreInterface) Exists(ctx context.Context, id int) (r0 bool) {
+	ctx, span := decorator.tracer.Start(ctx, "StoreInterface.Exists")
+	defer span.End()
+
+	r0 = decorator.next.Exists(ctx, id)
+	return
+}
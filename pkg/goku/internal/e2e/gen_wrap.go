@@ -0,0 +1,53 @@
+// Code generated by goku; DO NOT EDIT
+// Version: unknown
+// Command: goku decorate Store --wrap -o gen_wrap.go
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// force the decorator to implement the interface
+var _ StoreInterface = (*WrappingStoreInterface)(nil)
+
+// WrappingStoreInterface wraps errors returned from StoreInterface with the call that
+// returned them
+type WrappingStoreInterface struct {
+	next StoreInterface
+}
+
+// NewWrappingStoreInterface wraps errors returned from next with the method and
+// arguments of the call
+func NewWrappingStoreInterface(next StoreInterface) *WrappingStoreInterface {
+	return &WrappingStoreInterface{next: next}
+}
+
+func (decorator *WrappingStoreInterface) Get(ctx context.Context, id int) (r0 User, r1 error) {
+	r0, r1 = decorator.next.Get(ctx, id)
+	if r1 != nil {
+		r1 = fmt.Errorf("Store.Get(id=%v): %w", id, r1)
+	}
+	return
+}
+
+func (decorator *WrappingStoreInterface) Save(ctx context.Context, u User) (r0 error) {
+	r0 = decorator.next.Save(ctx, u)
+	if r0 != nil {
+		r0 = fmt.Errorf("Store.Save(): %w", r0)
+	}
+	return
+}
+
+func (decorator *WrappingStoreInterface) Count() (r0 int) {
+	return decorator.next.Count()
+}
+
+func (decorator *WrappingStoreInterface) Touch(t time.Time) {
+	decorator.next.Touch(t)
+}
+
+func (decorator *WrappingStoreInterface) Exists(ctx context.Context, id int) (r0 bool) {
+	return decorator.next.Exists(ctx, id)
+}
@@ -0,0 +1,49 @@
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestHooks(t *testing.T) {
+	store := NewHookedStoreInterface(NewStore(User{ID: 1, Name: "bob"}))
+
+	var calls []string
+	store.Before = append(store.Before, func(method string, args []any) {
+		calls = append(calls, fmt.Sprintf("before %s%v", method, args))
+	})
+	store.After = append(store.After, func(method string, results []any, dur time.Duration) {
+		calls = append(calls, fmt.Sprintf("after %s%v", method, results))
+	})
+
+	store.InterceptGet = func(next HookedStoreInterfaceGetFunc) HookedStoreInterfaceGetFunc {
+		return func(ctx context.Context, id int) (User, error) {
+			if id == 2 {
+				return User{ID: 2, Name: "intercepted"}, nil
+			}
+			return next(ctx, id)
+		}
+	}
+
+	if u, err := store.Get(context.Background(), 1); err != nil || u.Name != "bob" {
+		t.Fatalf("wanted bob, got %+v, %v", u, err)
+	}
+
+	if u, err := store.Get(context.Background(), 2); err != nil || u.Name != "intercepted" {
+		t.Fatalf("the interceptor should answer for 2, got %+v, %v", u, err)
+	}
+
+	store.Count()
+
+	want := []string{
+		"before Get[1]", "after Get[{1 bob} <nil>]",
+		"before Get[2]", "after Get[{2 intercepted} <nil>]",
+		"before Count[]", "after Count[1]",
+	}
+	if !slices.Equal(calls, want) {
+		t.Errorf("wanted %q, got %q", want, calls)
+	}
+}
@@ -0,0 +1,54 @@
+package e2e
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/AnthonyHewins/goku/pkg/goku/decorrt"
+)
+
+func TestLimit(mainTest *testing.T) {
+	cfg := decorrt.LimitConfig{Rate: 0.001}
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	mainTest.Run("per method", func(t *testing.T) {
+		store := NewLimitedStoreInterfacePerMethod(NewStore(User{ID: 1}), cfg)
+		if _, err := store.Get(context.Background(), 1); err != nil {
+			t.Fatalf("the first call should be allowed, got %v", err)
+		}
+
+		_, err := store.Get(canceled, 1)
+		if !errors.Is(err, decorrt.ErrLimited) || !errors.Is(err, context.Canceled) {
+			t.Errorf("wanted %v and %v, got %v", decorrt.ErrLimited, context.Canceled, err)
+		}
+
+		if err = store.Save(canceled, User{ID: 2}); err != nil {
+			t.Errorf("Save has its own limiter and should be allowed, got %v", err)
+		}
+	})
+
+	mainTest.Run("without an error", func(t *testing.T) {
+		store := NewLimitedStoreInterfacePerMethod(NewStore(User{ID: 1}), cfg)
+		if !store.Exists(context.Background(), 1) {
+			t.Fatal("the first call should be allowed")
+		}
+
+		if store.Exists(canceled, 1) {
+			t.Error("calls whose context is done should be skipped, returning zero values")
+		}
+	})
+
+	mainTest.Run("shared", func(t *testing.T) {
+		store := NewLimitedStoreInterface(NewStore(User{ID: 1}), decorrt.NewLimiter(cfg))
+		if _, err := store.Get(context.Background(), 1); err != nil {
+			t.Fatalf("the first call should be allowed, got %v", err)
+		}
+
+		if err := store.Save(canceled, User{ID: 2}); !errors.Is(err, decorrt.ErrLimited) {
+			t.Errorf("wanted %v, got %v", decorrt.ErrLimited, err)
+		}
+	})
+}
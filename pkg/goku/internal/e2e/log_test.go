@@ -0,0 +1,78 @@
+package e2e
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+)
+
+func TestLog(mainTest *testing.T) {
+	var buf bytes.Buffer
+	store := NewLoggingStoreInterface(NewStore(), slog.New(slog.NewJSONHandler(&buf, nil)))
+
+	testCases := []struct {
+		name    string
+		call    func()
+		level   string
+		args    map[string]any
+		results bool
+		err     string
+	}{
+		{
+			name:  "success",
+			call:  func() { store.Save(context.Background(), User{ID: 1, Name: "a"}) },
+			level: "INFO",
+			args:  map[string]any{"u": "[REDACTED]"},
+		},
+		{
+			name:    "results",
+			call:    func() { store.Count() },
+			level:   "INFO",
+			results: true,
+		},
+		{
+			name:  "failure",
+			call:  func() { store.Get(context.Background(), 42) },
+			level: "ERROR",
+			args:  map[string]any{"id": float64(42)},
+			err:   ErrNotFound.Error(),
+		},
+	}
+
+	for _, tc := range testCases {
+		mainTest.Run(tc.name, func(tt *testing.T) {
+			buf.Reset()
+			tc.call()
+
+			var line struct {
+				Level   string         `json:"level"`
+				Args    map[string]any `json:"args"`
+				Results map[string]any `json:"results"`
+				Err     string         `json:"error"`
+			}
+			if err := json.Unmarshal(buf.Bytes(), &line); err != nil {
+				tt.Fatalf("expected one JSON log line, got %q: %v", buf.String(), err)
+			}
+
+			if line.Level != tc.level {
+				tt.Errorf("wanted level %s, got %s", tc.level, line.Level)
+			}
+
+			for k, want := range tc.args {
+				if got := line.Args[k]; got != want {
+					tt.Errorf("wanted arg %s=%v, got %v", k, want, got)
+				}
+			}
+
+			if got := line.Results != nil; got != tc.results {
+				tt.Errorf("wanted results logged %v, got %v", tc.results, line.Results)
+			}
+
+			if line.Err != tc.err {
+				tt.Errorf("wanted error %q, got %q", tc.err, line.Err)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package e2e
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+type counters struct {
+	calls, errors map[string]int
+	latencies     map[string][]time.Duration
+}
+
+func (c *counters) IncCalls(method string)  { c.calls[method]++ }
+func (c *counters) IncErrors(method string) { c.errors[method]++ }
+func (c *counters) ObserveLatency(method string, d time.Duration) {
+	c.latencies[method] = append(c.latencies[method], d)
+}
+
+func TestMetrics(t *testing.T) {
+	c := &counters{calls: map[string]int{}, errors: map[string]int{}, latencies: map[string][]time.Duration{}}
+	store := NewInstrumentedStoreInterface(NewStore(User{ID: 1}), c)
+
+	store.Get(context.Background(), 1)
+	store.Get(context.Background(), 2)
+	store.Count()
+
+	if got := c.calls[StoreInterfaceMethodGet]; got != 2 {
+		t.Errorf("want 2 calls to Get, got %d", got)
+	}
+
+	if got := c.errors[StoreInterfaceMethodGet]; got != 1 {
+		t.Errorf("want 1 error from Get, got %d", got)
+	}
+
+	if got := len(c.latencies[StoreInterfaceMethodGet]); got != 2 {
+		t.Errorf("want 2 latencies for Get, got %d", got)
+	}
+
+	if got := c.calls[StoreInterfaceMethodCount]; got != 1 {
+		t.Errorf("want 1 call to Count, got %d", got)
+	}
+
+	if got := c.errors[StoreInterfaceMethodCount]; got != 0 {
+		t.Errorf("Count can't fail, got %d errors", got)
+	}
+}
@@ -0,0 +1,97 @@
+package e2e
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/AnthonyHewins/goku/pkg/goku/mockrt"
+)
+
+// recordingTB records failures instead of failing the test
+type recordingTB struct {
+	testing.TB
+	failed bool
+}
+
+func (r *recordingTB) Helper()               {}
+func (r *recordingTB) Errorf(string, ...any) { r.failed = true }
+
+func TestMockAssertions(t *testing.T) {
+	m := &MockStore{GetFn: func(ctx context.Context, id int) (User, error) { return User{ID: id}, nil }}
+
+	if u, err := m.Get(context.Background(), 1); err != nil || u.ID != 1 {
+		t.Fatalf("wanted GetFn's result, got %+v, %v", u, err)
+	}
+	m.Count()
+
+	m.AssertGetCalled(t, mockrt.Any(), 1)
+	m.AssertGetCalledTimes(t, 1)
+	m.AssertSaveNotCalled(t)
+
+	if len(m.CallsTo("Count")) != 1 {
+		t.Errorf("Count should be recorded, got %v", m.Calls())
+	}
+
+	// a recorder with T unset collects unexpected calls instead of failing
+	tb := &recordingTB{}
+	if m.AssertNoUnexpectedCalls(tb) || !tb.failed {
+		t.Error("Count has no CountFn, so it should be flagged unexpected")
+	}
+}
+
+func TestMockWait(t *testing.T) {
+	m := &MockStore{SaveFn: func(context.Context, User) error { return nil }}
+	defer m.Close()
+	called := m.SaveCalled()
+
+	go m.Save(context.Background(), User{ID: 2})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	call, err := m.WaitForSave(ctx)
+	if err != nil || call.U.ID != 2 {
+		t.Fatalf("wanted the call to Save with user 2, got %+v, %v", call, err)
+	}
+
+	select {
+	case c := <-called:
+		if c.Method != "Save" {
+			t.Errorf("wanted a call to Save, got %s", c)
+		}
+	case <-ctx.Done():
+		t.Fatal("the call should be sent on SaveCalled")
+	}
+
+	short, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err = m.WaitForSave(short); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("there's no second call to wait for, got %v", err)
+	}
+}
+
+func TestMockContext(t *testing.T) {
+	m := &MockStore{GetFn: func(context.Context, int) (User, error) { return User{}, nil }}
+	m.HonorContext = true
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := m.Get(canceled, 1); !errors.Is(err, context.Canceled) {
+		t.Errorf("HonorContext should return the context's error, got %v", err)
+	}
+
+	m.SetDelay("Get", time.Hour)
+	short, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := m.Get(short, 1); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("the delay should be cut short by the context, got %v", err)
+	}
+
+	m.SetDelay("Get", 5*time.Millisecond)
+	start := time.Now()
+	if _, err := m.Get(context.Background(), 1); err != nil || time.Since(start) < 5*time.Millisecond {
+		t.Errorf("Get should be delayed then succeed, got %v after %s", err, time.Since(start))
+	}
+}
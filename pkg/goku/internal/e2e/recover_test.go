@@ -0,0 +1,37 @@
+package e2e
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/AnthonyHewins/goku/pkg/goku/decorrt"
+)
+
+// panicky panics on every call
+type panicky struct{ StoreInterface }
+
+func (panicky) Get(context.Context, int) (User, error) { panic(errFlaky) }
+func (panicky) Touch(time.Time)                        { panic("touched") }
+
+func TestRecover(t *testing.T) {
+	var handled []*decorrt.PanicError
+	store := NewRecoveringStoreInterface(panicky{}, func(err *decorrt.PanicError) { handled = append(handled, err) })
+
+	_, err := store.Get(context.Background(), 1)
+
+	var panicErr *decorrt.PanicError
+	if !errors.As(err, &panicErr) || !errors.Is(err, errFlaky) {
+		t.Fatalf("wanted the panic returned as an error, got %v", err)
+	}
+
+	if panicErr.Method != "Get" || len(panicErr.Stack) == 0 {
+		t.Errorf("wanted Get's panic with a stack, got %+v", panicErr)
+	}
+
+	store.Touch(time.Now())
+	if len(handled) != 1 || handled[0].Value != "touched" {
+		t.Errorf("Touch's panic should be handled, got %v", handled)
+	}
+}
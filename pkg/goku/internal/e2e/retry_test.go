@@ -0,0 +1,59 @@
+package e2e
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/AnthonyHewins/goku/pkg/goku/decorrt"
+)
+
+var errFlaky = errors.New("flaky")
+
+// flaky fails the first failures calls to every method that can fail
+type flaky struct {
+	StoreInterface
+	failures, calls int
+}
+
+func (f *flaky) fail() bool {
+	f.calls++
+	return f.calls <= f.failures
+}
+
+func (f *flaky) Get(ctx context.Context, id int) (User, error) {
+	if f.fail() {
+		return User{}, errFlaky
+	}
+	return f.StoreInterface.Get(ctx, id)
+}
+
+func (f *flaky) Save(ctx context.Context, u User) error {
+	if f.fail() {
+		return errFlaky
+	}
+	return f.StoreInterface.Save(ctx, u)
+}
+
+func TestRetry(t *testing.T) {
+	next := &flaky{StoreInterface: NewStore(User{ID: 1, Name: "bob"}), failures: 2}
+	store := NewRetryingStoreInterface(next, decorrt.RetryPolicy{MaxAttempts: 3})
+
+	u, err := store.Get(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("should've recovered after retrying, got %v", err)
+	}
+
+	if u.Name != "bob" || next.calls != 3 {
+		t.Errorf("wanted bob after 3 calls, got %+v after %d", u, next.calls)
+	}
+
+	next.calls = 0
+	if err = store.Save(context.Background(), User{ID: 2}); !errors.Is(err, errFlaky) {
+		t.Errorf("Save is marked noretry and should fail on the first call, got %v", err)
+	}
+
+	if next.calls != 1 {
+		t.Errorf("wanted 1 call to Save, got %d", next.calls)
+	}
+}
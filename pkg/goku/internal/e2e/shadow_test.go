@@ -0,0 +1,49 @@
+package e2e
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/AnthonyHewins/goku/pkg/goku/decorrt"
+)
+
+func TestShadow(t *testing.T) {
+	var (
+		mu         sync.Mutex
+		mismatches []string
+	)
+
+	primary := NewStore(User{ID: 1, Name: "bob"}, User{ID: 2, Name: "alice"})
+	candidate := NewStore(User{ID: 1, Name: "bob"}, User{ID: 2, Name: "alicia"})
+	store := NewShadowStoreInterface(primary, candidate, func(m decorrt.Mismatch) {
+		mu.Lock()
+		defer mu.Unlock()
+		mismatches = append(mismatches, m.String())
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	for _, id := range []int{1, 2, 3} {
+		store.Get(ctx, id)
+	}
+	cancel()
+
+	if u, err := store.Get(context.Background(), 2); err != nil || u.Name != "alice" {
+		t.Errorf("results should come from the primary, got %+v, %v", u, err)
+	}
+
+	if err := store.Save(context.Background(), User{ID: 3}); err != nil {
+		t.Fatal(err)
+	}
+
+	store.Wait()
+
+	if _, err := candidate.Get(context.Background(), 3); err == nil {
+		t.Error("Save is annotated noshadow, so the candidate shouldn't be called")
+	}
+
+	want := "Get[2]: primary returned [{2 alice} <nil>], candidate returned [{2 alicia} <nil>]"
+	if len(mismatches) != 2 || mismatches[0] != want || mismatches[1] != want {
+		t.Errorf("wanted Get(2) reported twice as %q, got %q", want, mismatches)
+	}
+}
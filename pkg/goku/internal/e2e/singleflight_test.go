@@ -0,0 +1,157 @@
+package e2e
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// gated blocks calls to Get until it's opened, counting them. Calls whose
+// context is done by then fail
+type gated struct {
+	StoreInterface
+	calls atomic.Int32
+	gate  chan struct{}
+}
+
+func (g *gated) Get(ctx context.Context, id int) (User, error) {
+	g.calls.Add(1)
+	<-g.gate
+	if err := ctx.Err(); err != nil {
+		return User{}, err
+	}
+	return g.StoreInterface.Get(ctx, id)
+}
+
+func (g *gated) Exists(ctx context.Context, id int) bool {
+	g.calls.Add(1)
+	<-g.gate
+	return ctx.Err() == nil && g.StoreInterface.Exists(ctx, id)
+}
+
+func TestSingleflight(t *testing.T) {
+	next := &gated{StoreInterface: NewStore(User{ID: 1, Name: "bob"}), gate: make(chan struct{})}
+	store := NewSingleflightStoreInterface(next)
+
+	var wg sync.WaitGroup
+	users := make([]User, 5)
+	for i := range users {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			users[i], _ = store.Get(context.Background(), 1)
+		}()
+	}
+
+	// give every call a chance to pile up behind the first
+	time.Sleep(50 * time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := store.Get(ctx, 1); err != context.Canceled {
+		t.Errorf("a caller should stop waiting when its context is done, got %v", err)
+	}
+	close(next.gate)
+	wg.Wait()
+
+	for _, u := range users {
+		if u.Name != "bob" {
+			t.Errorf("every caller should get bob, got %+v", u)
+		}
+	}
+
+	if n := next.calls.Load(); n != 1 {
+		t.Errorf("wanted concurrent calls collapsed into 1, got %d", n)
+	}
+}
+
+func TestSingleflightFirstCallerCancels(t *testing.T) {
+	next := &gated{StoreInterface: NewStore(User{ID: 1, Name: "bob"}), gate: make(chan struct{})}
+	store := NewSingleflightStoreInterface(next)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error)
+	go func() {
+		_, err := store.Get(ctx, 1)
+		first <- err
+	}()
+
+	for next.calls.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	var wg sync.WaitGroup
+	users, errs := make([]User, 3), make([]error, 3)
+	for i := range users {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			users[i], errs[i] = store.Get(context.Background(), 1)
+		}()
+	}
+
+	// give every call a chance to pile up behind the first
+	time.Sleep(50 * time.Millisecond)
+
+	cancel()
+	if err := <-first; err != context.Canceled {
+		t.Errorf("the first caller should stop waiting when its context is done, got %v", err)
+	}
+	close(next.gate)
+	wg.Wait()
+
+	for i, u := range users {
+		if errs[i] != nil || u.Name != "bob" {
+			t.Errorf("the first caller giving up shouldn't fail the rest, got %+v, %v", u, errs[i])
+		}
+	}
+
+	if n := next.calls.Load(); n != 1 {
+		t.Errorf("wanted concurrent calls collapsed into 1, got %d", n)
+	}
+}
+
+func TestSingleflightFirstCallerCancelsWithoutError(t *testing.T) {
+	next := &gated{StoreInterface: NewStore(User{ID: 1, Name: "bob"}), gate: make(chan struct{})}
+	store := NewSingleflightStoreInterface(next)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan bool)
+	go func() { first <- store.Exists(ctx, 1) }()
+
+	for next.calls.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	var wg sync.WaitGroup
+	exists := make([]bool, 3)
+	for i := range exists {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			exists[i] = store.Exists(context.Background(), 1)
+		}()
+	}
+
+	// give every call a chance to pile up behind the first
+	time.Sleep(50 * time.Millisecond)
+
+	cancel()
+	if <-first {
+		t.Error("the first caller should stop waiting with zero values when its context is done")
+	}
+	close(next.gate)
+	wg.Wait()
+
+	for i, ok := range exists {
+		if !ok {
+			t.Errorf("caller %d: the first caller giving up shouldn't fail the rest", i)
+		}
+	}
+
+	if n := next.calls.Load(); n != 1 {
+		t.Errorf("wanted concurrent calls collapsed into 1, got %d", n)
+	}
+}
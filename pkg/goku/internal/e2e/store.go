@@ -0,0 +1,89 @@
+// Package e2e holds code generated by goku, so the tests here can check it
+// compiles and behaves against the real libraries it's generated for
+package e2e
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+//go:generate goku iface Store -m MockStore --mock-out gen_mock_test.go -o gen_iface.go
+//go:generate goku decorate Store --trace -o gen_trace.go
+//go:generate goku decorate Store --metrics -o gen_metrics.go
+//go:generate goku decorate Store --retry -o gen_retry.go
+//go:generate goku decorate Store --timeout -o gen_timeout.go
+//go:generate goku decorate Store --sync --rw -o gen_sync.go
+//go:generate goku decorate Store --cache -o gen_cache.go
+//go:generate goku decorate Store --limit -o gen_limit.go
+//go:generate goku decorate Store --record -o gen_record.go
+//go:generate goku decorate Store --replay -o gen_replay.go
+//go:generate goku decorate Store --recover -o gen_recover.go
+//go:generate goku decorate Store --hooks -o gen_hooks.go
+//go:generate goku decorate Store --faults -o gen_faults.go
+//go:generate goku decorate Store --singleflight -o gen_singleflight.go
+//go:generate goku decorate Store --wrap -o gen_wrap.go
+//go:generate goku decorate Store --authz -o gen_authz.go
+//go:generate goku decorate Store --log -o gen_log.go
+//go:generate goku compose Store --tee -o gen_tee.go
+//go:generate goku compose Store --fallback -o gen_fallback.go
+//go:generate goku compose Store --shadow -o gen_shadow.go
+
+var ErrNotFound = errors.New("not found")
+
+type User struct {
+	ID   int
+	Name string
+}
+
+// Store is an in-memory user store
+type Store struct {
+	users map[int]User
+	now   time.Time
+}
+
+func NewStore(users ...User) *Store {
+	s := &Store{users: map[int]User{}}
+	for _, v := range users {
+		s.users[v.ID] = v
+	}
+	return s
+}
+
+//goku:trace id
+//goku:timeout 10ms
+//goku:cache ttl=1m size=2
+//goku:singleflight
+//goku:log id results=false
+func (s *Store) Get(ctx context.Context, id int) (User, error) {
+	u, ok := s.users[id]
+	if !ok {
+		return User{}, ErrNotFound
+	}
+	return u, nil
+}
+
+//goku:noretry
+//goku:noshadow
+//goku:authz role=admin
+//goku:redact u
+func (s *Store) Save(ctx context.Context, u User) error {
+	s.users[u.ID] = u
+	return nil
+}
+
+//goku:read
+//goku:noauthz
+func (s *Store) Count() int { return len(s.users) }
+
+//goku:trace t
+//goku:noauthz
+func (s *Store) Touch(t time.Time) { s.now = t }
+
+//goku:read
+//goku:noauthz
+//goku:singleflight
+func (s *Store) Exists(ctx context.Context, id int) bool {
+	_, ok := s.users[id]
+	return ok
+}
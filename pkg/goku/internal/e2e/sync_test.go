@@ -0,0 +1,33 @@
+package e2e
+
+import (
+	"context"
+	"sync"
+	"testing"
+)
+
+func TestSynchronized(t *testing.T) {
+	store := NewSynchronizedStoreInterface(NewStore())
+
+	var wg sync.WaitGroup
+	for i := range 50 {
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			store.Save(context.Background(), User{ID: i})
+		}()
+		go func() {
+			defer wg.Done()
+			store.Get(context.Background(), i)
+		}()
+		go func() {
+			defer wg.Done()
+			store.Count()
+		}()
+	}
+	wg.Wait()
+
+	if got := store.Count(); got != 50 {
+		t.Errorf("wanted 50 users, got %d", got)
+	}
+}
@@ -0,0 +1,47 @@
+package e2e
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/AnthonyHewins/goku/pkg/goku/decorrt"
+)
+
+func TestTee(mainTest *testing.T) {
+	testCases := []struct {
+		name   string
+		config decorrt.TeeConfig
+	}{
+		{name: "sequential", config: decorrt.TeeConfig{Policy: decorrt.TeeJoinErrors}},
+		{name: "parallel", config: decorrt.TeeConfig{Policy: decorrt.TeeJoinErrors, Parallel: true}},
+	}
+
+	for _, tc := range testCases {
+		mainTest.Run(tc.name, func(tt *testing.T) {
+			primary, secondary := NewStore(User{ID: 1, Name: "bob"}), NewStore()
+			tee := NewTeeStoreInterface(tc.config, primary, secondary)
+
+			if err := tee.Save(context.Background(), User{ID: 2, Name: "alice"}); err != nil {
+				tt.Fatalf("wanted no error, got %v", err)
+			}
+
+			if primary.Count() != 2 || secondary.Count() != 1 {
+				tt.Errorf("every store should've been written to, got %d and %d users", primary.Count(), secondary.Count())
+			}
+
+			if got := tee.Count(); got != 2 {
+				tt.Errorf("results should come from the first store, got %d", got)
+			}
+
+			u, err := tee.Get(context.Background(), 1)
+			if !errors.Is(err, ErrNotFound) {
+				tt.Errorf("the second store's error should be joined in, got %v", err)
+			}
+
+			if u.Name != "bob" {
+				tt.Errorf("results should come from the first store, got %+v", u)
+			}
+		})
+	}
+}
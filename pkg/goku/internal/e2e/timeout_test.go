@@ -0,0 +1,59 @@
+package e2e
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/AnthonyHewins/goku/pkg/goku/decorrt"
+)
+
+// slow blocks every call that can fail until its context is done. If it
+// ignores the context, it waits for the deadline but returns its own error
+type slow struct {
+	StoreInterface
+	ignoreCtx bool
+}
+
+func (s *slow) wait(ctx context.Context) error {
+	<-ctx.Done()
+	if s.ignoreCtx {
+		return errFlaky
+	}
+	return ctx.Err()
+}
+
+func (s *slow) Get(ctx context.Context, id int) (User, error) { return User{}, s.wait(ctx) }
+func (s *slow) Save(ctx context.Context, u User) error        { return s.wait(ctx) }
+
+func TestTimeout(mainTest *testing.T) {
+	testCases := []struct {
+		name      string
+		ignoreCtx bool
+		wrapped   bool
+	}{
+		{name: "honors ctx"},
+		{name: "ignores ctx", ignoreCtx: true, wrapped: true},
+	}
+
+	for _, tc := range testCases {
+		mainTest.Run(tc.name, func(tt *testing.T) {
+			store := NewTimeoutStoreInterface(&slow{ignoreCtx: tc.ignoreCtx}, map[string]time.Duration{"Save": time.Millisecond})
+
+			_, err := store.Get(context.Background(), 1)
+			if !errors.Is(err, context.DeadlineExceeded) {
+				tt.Errorf("Get should use the deadline in its directive, got %v", err)
+			}
+
+			var timeoutErr *decorrt.TimeoutError
+			if got := errors.As(err, &timeoutErr); got != tc.wrapped {
+				tt.Errorf("wanted wrapped=%v but got %v", tc.wrapped, err)
+			}
+
+			if err = store.Save(context.Background(), User{}); !errors.Is(err, context.DeadlineExceeded) {
+				tt.Errorf("Save should use the deadline it was given, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package e2e
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func tracing(base context.Context) (*TracingStoreInterface, *tracetest.SpanRecorder) {
+	rec := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec)).Tracer("e2e")
+	return NewTracingStoreInterface(NewStore(User{ID: 1, Name: "bob"}), tracer, base), rec
+}
+
+func TestTracing(mainTest *testing.T) {
+	mainTest.Run("ctx method", func(t *testing.T) {
+		store, rec := tracing(nil)
+		if _, err := store.Get(context.Background(), 1); err != nil {
+			t.Fatal(err)
+		}
+
+		spans := rec.Ended()
+		if len(spans) != 1 {
+			t.Fatalf("want 1 span, got %d", len(spans))
+		}
+
+		span := spans[0]
+		if got := span.Name(); got != "StoreInterface.Get" {
+			t.Errorf("want span named StoreInterface.Get, got %s", got)
+		}
+
+		if got := span.Status().Code; got != codes.Unset {
+			t.Errorf("successful call should leave status unset, got %s", got)
+		}
+
+		want := attribute.Int("id", 1)
+		if attrs := span.Attributes(); len(attrs) != 1 || attrs[0] != want {
+			t.Errorf("want attributes [%v], got %v", want, attrs)
+		}
+	})
+
+	mainTest.Run("child of ctx span", func(t *testing.T) {
+		store, rec := tracing(nil)
+		ctx, parent := store.tracer.Start(context.Background(), "parent")
+		store.Save(ctx, User{ID: 2})
+		parent.End()
+
+		spans := rec.Ended()
+		if len(spans) != 2 {
+			t.Fatalf("want 2 spans, got %d", len(spans))
+		}
+
+		if got, want := spans[0].Parent().SpanID(), spans[1].SpanContext().SpanID(); got != want {
+			t.Errorf("Save's span should be a child of the parent: got parent %s, want %s", got, want)
+		}
+	})
+
+	mainTest.Run("error", func(t *testing.T) {
+		store, rec := tracing(nil)
+		if _, err := store.Get(context.Background(), 2); err != ErrNotFound {
+			t.Fatalf("want %v, got %v", ErrNotFound, err)
+		}
+
+		span := rec.Ended()[0]
+		if got := span.Status(); got.Code != codes.Error || got.Description != ErrNotFound.Error() {
+			t.Errorf("want error status %q, got %s %q", ErrNotFound, got.Code, got.Description)
+		}
+
+		if events := span.Events(); len(events) != 1 || events[0].Name != "exception" {
+			t.Errorf("want the error recorded as an exception event, got %v", events)
+		}
+	})
+
+	mainTest.Run("no ctx without base", func(t *testing.T) {
+		store, rec := tracing(nil)
+		if got := store.Count(); got != 1 {
+			t.Errorf("want count 1, got %d", got)
+		}
+
+		store.Touch(time.Now())
+		if spans := rec.Ended(); len(spans) != 0 {
+			t.Errorf("methods without a context shouldn't be traced without a base, got %d spans", len(spans))
+		}
+	})
+
+	mainTest.Run("no ctx with base", func(t *testing.T) {
+		store, rec := tracing(context.Background())
+		now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+		store.Touch(now)
+
+		spans := rec.Ended()
+		if len(spans) != 1 {
+			t.Fatalf("want 1 span, got %d", len(spans))
+		}
+
+		want := attribute.String("t", now.String())
+		if attrs := spans[0].Attributes(); len(attrs) != 1 || attrs[0] != want {
+			t.Errorf("want attributes [%v], got %v", want, attrs)
+		}
+	})
+}
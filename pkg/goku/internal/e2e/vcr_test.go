@@ -0,0 +1,53 @@
+package e2e
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/AnthonyHewins/goku/pkg/goku/decorrt"
+)
+
+func TestRecordReplay(t *testing.T) {
+	cassette := decorrt.NewCassette()
+	recorder := NewRecordingStoreInterface(NewStore(User{ID: 1, Name: "bob"}), cassette)
+
+	recorder.Get(context.Background(), 1)
+	recorder.Get(context.Background(), 2)
+	recorder.Count()
+
+	path := filepath.Join(t.TempDir(), "store.json")
+	if err := cassette.Save(path); err != nil {
+		t.Fatalf("should save the cassette, got %v", err)
+	}
+
+	cassette, err := decorrt.LoadCassette(path)
+	if err != nil {
+		t.Fatalf("should load the cassette, got %v", err)
+	}
+
+	replayer := NewReplayingStoreInterface(cassette)
+	if u, err := replayer.Get(context.Background(), 1); err != nil || u.Name != "bob" {
+		t.Errorf("wanted bob, got %+v, %v", u, err)
+	}
+
+	if _, err = replayer.Get(context.Background(), 2); !errors.Is(err, ErrNotFound) {
+		t.Errorf("the recorded error should match %v, got %v", ErrNotFound, err)
+	}
+
+	if got := replayer.Count(); got != 1 {
+		t.Errorf("wanted 1, got %d", got)
+	}
+
+	if _, err = replayer.Get(context.Background(), 3); !errors.Is(err, decorrt.ErrUnmatched) {
+		t.Errorf("wanted %v, got %v", decorrt.ErrUnmatched, err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("unmatched calls to methods that can't fail should panic")
+		}
+	}()
+	replayer.Count()
+}
@@ -0,0 +1,24 @@
+package e2e
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestWrap(t *testing.T) {
+	store := NewWrappingStoreInterface(NewStore())
+
+	_, err := store.Get(context.Background(), 42)
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("wrapped errors should still match %v, got %v", ErrNotFound, err)
+	}
+
+	if got, want := err.Error(), "Store.Get(id=42): not found"; got != want {
+		t.Errorf("wanted %q, got %q", want, got)
+	}
+
+	if err = store.Save(context.Background(), User{ID: 1}); err != nil {
+		t.Errorf("nil errors should stay nil, got %v", err)
+	}
+}
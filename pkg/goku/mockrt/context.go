@@ -0,0 +1,47 @@
+package mockrt
+
+import (
+	"context"
+	"time"
+)
+
+// Delay every call to method by d before it reaches its implementation.
+// Only methods whose first argument is a context are delayed, and the delay
+// is cut short if that context is done first
+func (r *Recorder) SetDelay(method string, d time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.delays == nil {
+		r.delays = map[string]time.Duration{}
+	}
+	r.delays[method] = d
+}
+
+// Await is called by mocks before running the implementation of a method
+// whose first argument is a context. It applies the method's delay, and
+// returns ctx.Err() if the delay was cut short or HonorContext is set and
+// ctx is already done
+func (r *Recorder) Await(ctx context.Context, method string) error {
+	r.mu.Lock()
+	honor, delay := r.HonorContext, r.delays[method]
+	r.mu.Unlock()
+
+	if honor && ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	if delay <= 0 {
+		return nil
+	}
+
+	t := time.NewTimer(delay)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
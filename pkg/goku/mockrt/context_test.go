@@ -0,0 +1,37 @@
+package mockrt
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestAwait(mainTest *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	testCases := []struct {
+		name        string
+		honor       bool
+		delay       time.Duration
+		ctx         context.Context
+		expectedErr error
+	}{
+		{name: "no config", ctx: canceled},
+		{name: "honors done context", honor: true, ctx: canceled, expectedErr: context.Canceled},
+		{name: "delay", delay: time.Millisecond, ctx: context.Background()},
+		{name: "delay cut short", delay: time.Hour, ctx: canceled, expectedErr: context.Canceled},
+	}
+
+	for _, tc := range testCases {
+		mainTest.Run(tc.name, func(tt *testing.T) {
+			r := Recorder{HonorContext: tc.honor}
+			r.SetDelay("Get", tc.delay)
+
+			if err := r.Await(tc.ctx, "Get"); !errors.Is(err, tc.expectedErr) {
+				tt.Errorf("wanted %v but got %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package mockrt
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Matcher decides whether an argument a mock was called with is acceptable.
+// Anything passed to an assertion that isn't a Matcher is wrapped in Eq
+type Matcher interface {
+	Match(arg any) bool
+	String() string
+}
+
+type anyMatcher struct{}
+
+// Any matches every argument
+func Any() Matcher { return anyMatcher{} }
+
+func (anyMatcher) Match(any) bool { return true }
+func (anyMatcher) String() string { return "Any()" }
+
+type eqMatcher struct {
+	want  any
+	equal func(want, got any) bool
+}
+
+// Eq matches arguments deeply equal to want
+func Eq(want any) Matcher { return eqMatcher{want, reflect.DeepEqual} }
+
+func (e eqMatcher) Match(arg any) bool { return e.equal(e.want, arg) }
+func (e eqMatcher) String() string     { return formatArg(e.want) }
+
+type funcMatcher[T any] struct{ pred func(T) bool }
+
+// Func matches arguments of type T that satisfy pred. Arguments of any other
+// type never match
+func Func[T any](pred func(T) bool) Matcher { return funcMatcher[T]{pred} }
+
+func (f funcMatcher[T]) Match(arg any) bool {
+	x, ok := arg.(T)
+	if !ok {
+		// nil interfaces and pointers won't type assert, but they're still a T
+		if arg != nil || reflect.TypeFor[T]().Kind() != reflect.Interface {
+			return false
+		}
+	}
+
+	return f.pred(x)
+}
+
+func (f funcMatcher[T]) String() string { return fmt.Sprintf("Func[%s](pred)", reflect.TypeFor[T]()) }
+
+type containsMatcher struct{ elem any }
+
+// Contains matches strings containing a substring, slices and arrays containing
+// an element, and maps containing a key
+func Contains(elem any) Matcher { return containsMatcher{elem} }
+
+func (c containsMatcher) Match(arg any) bool {
+	if s, ok := arg.(string); ok {
+		sub, ok := c.elem.(string)
+		return ok && strings.Contains(s, sub)
+	}
+
+	v := reflect.ValueOf(arg)
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := range v.Len() {
+			if reflect.DeepEqual(v.Index(i).Interface(), c.elem) {
+				return true
+			}
+		}
+	case reflect.Map:
+		k := reflect.ValueOf(c.elem)
+		if !k.IsValid() || !k.Type().AssignableTo(v.Type().Key()) {
+			return false
+		}
+		return v.MapIndex(k).IsValid()
+	}
+
+	return false
+}
+
+func (c containsMatcher) String() string { return "Contains(" + formatArg(c.elem) + ")" }
+
+// asMatcher wraps anything that isn't already a Matcher in an equality
+// check using equal, or reflect.DeepEqual if equal is nil
+func asMatcher(x any, equal func(want, got any) bool) Matcher {
+	if m, ok := x.(Matcher); ok {
+		return m
+	}
+
+	if equal == nil {
+		equal = reflect.DeepEqual
+	}
+
+	return eqMatcher{x, equal}
+}
+
+// formatArg prints values the way they'd read in a test failure: types that
+// describe themselves do so, everything else prints as Go syntax
+func formatArg(x any) string {
+	switch x.(type) {
+	case fmt.Stringer, error:
+		return fmt.Sprintf("%v", x)
+	default:
+		return fmt.Sprintf("%#v", x)
+	}
+}
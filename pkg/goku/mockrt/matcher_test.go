@@ -0,0 +1,41 @@
+package mockrt
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMatchers(mainTest *testing.T) {
+	testCases := []struct {
+		name     string
+		matcher  Matcher
+		arg      any
+		expected bool
+	}{
+		{name: "any", matcher: Any(), arg: 1, expected: true},
+		{name: "any nil", matcher: Any(), arg: nil, expected: true},
+		{name: "eq", matcher: Eq(1), arg: 1, expected: true},
+		{name: "eq deep", matcher: Eq([]int{1, 2}), arg: []int{1, 2}, expected: true},
+		{name: "eq wrong type", matcher: Eq(1), arg: int64(1)},
+		{name: "func", matcher: Func(func(x int) bool { return x > 1 }), arg: 2, expected: true},
+		{name: "func false", matcher: Func(func(x int) bool { return x > 1 }), arg: 1},
+		{name: "func wrong type", matcher: Func(func(x int) bool { return true }), arg: "x"},
+		{name: "func nil iface", matcher: Func(func(x error) bool { return x == nil }), arg: nil, expected: true},
+		{name: "func iface", matcher: Func(func(x error) bool { return x != nil }), arg: errors.New("x"), expected: true},
+		{name: "contains substring", matcher: Contains("ell"), arg: "hello", expected: true},
+		{name: "contains missing substring", matcher: Contains("z"), arg: "hello"},
+		{name: "contains slice", matcher: Contains(2), arg: []int{1, 2}, expected: true},
+		{name: "contains missing slice", matcher: Contains(3), arg: []int{1, 2}},
+		{name: "contains map key", matcher: Contains("k"), arg: map[string]int{"k": 1}, expected: true},
+		{name: "contains map wrong key type", matcher: Contains(1), arg: map[string]int{"k": 1}},
+		{name: "contains unsupported", matcher: Contains(1), arg: 1},
+	}
+
+	for _, tc := range testCases {
+		mainTest.Run(tc.name, func(tt *testing.T) {
+			if got := tc.matcher.Match(tc.arg); got != tc.expected {
+				tt.Errorf("%s.Match(%#v) wanted %t but got %t", tc.matcher, tc.arg, tc.expected, got)
+			}
+		})
+	}
+}
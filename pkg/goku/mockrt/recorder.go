@@ -0,0 +1,215 @@
+// Package mockrt is the runtime shared by every mock goku generates: the
+// call log, argument matchers and test reporting live here so generated
+// mocks stay thin typed shims
+package mockrt
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Call is a single invocation of a mocked method
+type Call struct {
+	Method string
+	Args   []any
+}
+
+func (c Call) String() string {
+	args := make([]string, len(c.Args))
+	for i, v := range c.Args {
+		args[i] = formatArg(v)
+	}
+
+	return c.Method + "(" + strings.Join(args, ", ") + ")"
+}
+
+// Recorder is the call log every generated mock embeds. It's safe for
+// concurrent use; the zero value is ready to go
+type Recorder struct {
+	// If set, unexpected calls fail the test the moment they happen
+	// instead of waiting for AssertNoUnexpectedCalls
+	T testing.TB
+
+	// Compares expected arguments to recorded ones when an assertion is
+	// given a plain value instead of a Matcher. Defaults to reflect.DeepEqual
+	Equal func(want, got any) bool
+
+	// If set, methods whose first argument is a context return ctx.Err()
+	// without reaching their implementation once that context is done
+	HonorContext bool
+
+	mu         sync.Mutex
+	calls      []Call
+	unexpected []Call
+
+	delays  map[string]time.Duration
+	waited  map[string]int
+	changed chan struct{}
+	subs    map[string]*subscription
+}
+
+// Record a call to method
+func (r *Recorder) Record(method string, args ...any) {
+	c := Call{Method: method, Args: args}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, c)
+	r.notify(c)
+}
+
+// Flag a call to method that the mock had no implementation for
+func (r *Recorder) Unexpected(method string, args ...any) {
+	c := Call{Method: method, Args: args}
+
+	r.mu.Lock()
+	r.unexpected = append(r.unexpected, c)
+	r.mu.Unlock()
+
+	if r.T != nil {
+		r.T.Helper()
+		r.T.Errorf("unexpected call to %s: the mock has no implementation for it", c)
+	}
+}
+
+// All calls recorded so far, in order
+func (r *Recorder) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Call(nil), r.calls...)
+}
+
+// All calls to method recorded so far, in order
+func (r *Recorder) CallsTo(method string) []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var calls []Call
+	for _, v := range r.calls {
+		if v.Method == method {
+			calls = append(calls, v)
+		}
+	}
+
+	return calls
+}
+
+// Forget every call recorded so far, including which ones WaitFor returned
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls, r.unexpected, r.waited = nil, nil, nil
+}
+
+// Assert method was called at least once with arguments matching args.
+// Arguments that aren't a Matcher are compared with Eq
+func (r *Recorder) AssertCalled(t testing.TB, method string, args ...any) bool {
+	t.Helper()
+
+	matchers := make([]Matcher, len(args))
+	for i, v := range args {
+		matchers[i] = asMatcher(v, r.Equal)
+	}
+
+	calls := r.CallsTo(method)
+	for _, c := range calls {
+		if len(mismatches(matchers, c.Args)) == 0 {
+			return true
+		}
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "expected call %s(%s), but ", method, joinMatchers(matchers))
+	if len(calls) == 0 {
+		sb.WriteString("there were no calls")
+	} else {
+		fmt.Fprintf(&sb, "got %d call(s):", len(calls))
+	}
+
+	for _, c := range calls {
+		sb.WriteString("\n\t" + c.String())
+		for _, v := range mismatches(matchers, c.Args) {
+			sb.WriteString("\n\t\t" + v)
+		}
+	}
+
+	t.Errorf("%s", sb.String())
+	return false
+}
+
+// Assert method was called exactly n times
+func (r *Recorder) AssertCalledTimes(t testing.TB, method string, n int) bool {
+	t.Helper()
+
+	calls := r.CallsTo(method)
+	if len(calls) == n {
+		return true
+	}
+
+	t.Errorf("expected %s to be called %d time(s), but %s", method, n, describe(calls))
+	return false
+}
+
+// Assert method was never called
+func (r *Recorder) AssertNotCalled(t testing.TB, method string) bool {
+	t.Helper()
+	return r.AssertCalledTimes(t, method, 0)
+}
+
+// Assert the mock was never called on a method it had no implementation for
+func (r *Recorder) AssertNoUnexpectedCalls(t testing.TB) bool {
+	t.Helper()
+
+	r.mu.Lock()
+	unexpected := append([]Call(nil), r.unexpected...)
+	r.mu.Unlock()
+
+	if len(unexpected) == 0 {
+		return true
+	}
+
+	t.Errorf("expected no unexpected calls, but %s", describe(unexpected))
+	return false
+}
+
+// mismatches describes every argument in args that its matcher rejects
+func mismatches(matchers []Matcher, args []any) []string {
+	if len(matchers) != len(args) {
+		return []string{fmt.Sprintf("want %d argument(s), got %d", len(matchers), len(args))}
+	}
+
+	var diffs []string
+	for i, m := range matchers {
+		if !m.Match(args[i]) {
+			diffs = append(diffs, fmt.Sprintf("argument %d: want %s, got %s", i, m, formatArg(args[i])))
+		}
+	}
+
+	return diffs
+}
+
+func joinMatchers(matchers []Matcher) string {
+	s := make([]string, len(matchers))
+	for i, v := range matchers {
+		s[i] = v.String()
+	}
+	return strings.Join(s, ", ")
+}
+
+func describe(calls []Call) string {
+	if len(calls) == 0 {
+		return "there were no calls"
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "got %d call(s):", len(calls))
+	for _, v := range calls {
+		sb.WriteString("\n\t")
+		sb.WriteString(v.String())
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,95 @@
+package mockrt
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type fakeTB struct {
+	testing.TB
+	errs []string
+}
+
+func (f *fakeTB) Helper() {}
+
+func (f *fakeTB) Errorf(format string, args ...any) {
+	f.errs = append(f.errs, fmt.Sprintf(format, args...))
+}
+
+func TestRecorder(t *testing.T) {
+	var r Recorder
+	r.Record("Get", 1, "x")
+	r.Record("Get", 2, "y")
+	r.Record("Put", 3)
+	r.Unexpected("Put", 3)
+
+	if n := len(r.Calls()); n != 3 {
+		t.Errorf("wanted 3 calls but got %d", n)
+	}
+
+	if n := len(r.CallsTo("Get")); n != 2 {
+		t.Errorf("wanted 2 calls to Get but got %d", n)
+	}
+
+	passing := &fakeTB{}
+	r.AssertCalled(passing, "Get", 2, Any())
+	r.AssertCalled(passing, "Put", Func(func(x int) bool { return x == 3 }))
+	r.AssertCalledTimes(passing, "Get", 2)
+	r.AssertNotCalled(passing, "Delete")
+	if len(passing.errs) != 0 {
+		t.Errorf("assertions should have passed, got %v", passing.errs)
+	}
+
+	failing := &fakeTB{}
+	r.AssertCalled(failing, "Get", 3, "x")
+	r.AssertCalledTimes(failing, "Put", 2)
+	r.AssertNotCalled(failing, "Get")
+	r.AssertNoUnexpectedCalls(failing)
+	if len(failing.errs) != 4 {
+		t.Fatalf("wanted 4 failures but got %v", failing.errs)
+	}
+
+	want := `expected call Get(3, "x"), but got 2 call(s):
+	Get(1, "x")
+		argument 0: want 3, got 1
+	Get(2, "y")
+		argument 0: want 3, got 2
+		argument 1: want "x", got "y"`
+	if failing.errs[0] != want {
+		t.Errorf("wanted failure\n%s\ngot\n%s", want, failing.errs[0])
+	}
+
+	r.Reset()
+	if n := len(r.Calls()); n != 0 {
+		t.Errorf("reset should clear calls, got %d", n)
+	}
+
+	r.AssertNoUnexpectedCalls(passing)
+	if len(passing.errs) != 0 {
+		t.Errorf("reset should clear unexpected calls, got %v", passing.errs)
+	}
+}
+
+func TestRecorderEqual(t *testing.T) {
+	r := Recorder{Equal: func(want, got any) bool {
+		return strings.EqualFold(want.(string), got.(string))
+	}}
+	r.Record("Get", "KEY")
+
+	tb := &fakeTB{}
+	r.AssertCalled(tb, "Get", "key")
+	if len(tb.errs) != 0 {
+		t.Errorf("custom equality should have matched, got %v", tb.errs)
+	}
+}
+
+func TestRecorderFailsFast(t *testing.T) {
+	tb := &fakeTB{}
+	r := Recorder{T: tb}
+	r.Unexpected("Get", 1)
+
+	if want := []string{"unexpected call to Get(1): the mock has no implementation for it"}; len(tb.errs) != 1 || tb.errs[0] != want[0] {
+		t.Errorf("wanted %v but got %v", want, tb.errs)
+	}
+}
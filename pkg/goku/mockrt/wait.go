@@ -0,0 +1,162 @@
+package mockrt
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// WaitFor blocks until there's a call to method that no previous WaitFor has
+// returned, then returns it. Calls come back in the order they were made, so
+// a test can step through a background worker's interactions one at a time
+func (r *Recorder) WaitFor(ctx context.Context, method string) (Call, error) {
+	for {
+		r.mu.Lock()
+		seen, n := r.waited[method], 0
+		for _, c := range r.calls {
+			if c.Method != method {
+				continue
+			}
+
+			if n == seen {
+				if r.waited == nil {
+					r.waited = map[string]int{}
+				}
+				r.waited[method]++
+				r.mu.Unlock()
+				return c, nil
+			}
+			n++
+		}
+
+		if r.changed == nil {
+			r.changed = make(chan struct{})
+		}
+		changed := r.changed
+		r.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return Call{}, fmt.Errorf("waiting for a call to %s: %w", method, ctx.Err())
+		case <-changed:
+		}
+	}
+}
+
+// Called returns a channel that receives every call to method made from now
+// on, in order. Recording never blocks on it: calls queue up until they're
+// read. Every caller gets the same channel for the same method, until Close
+// closes it
+func (r *Recorder) Called(method string) <-chan Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if s, ok := r.subs[method]; ok {
+		return s.ch
+	}
+
+	if r.subs == nil {
+		r.subs = map[string]*subscription{}
+		if r.T != nil {
+			r.T.Cleanup(r.Close)
+		}
+	}
+
+	s := &subscription{
+		wake:    make(chan struct{}, 1),
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+		ch:      make(chan Call),
+	}
+	r.subs[method] = s
+	go s.pump()
+	return s.ch
+}
+
+// Close the channels Called returned, dropping calls they haven't delivered,
+// and stop the goroutines feeding them. Once it returns, nothing more is
+// delivered. Mocks with T set are closed when the test finishes
+func (r *Recorder) Close() {
+	r.mu.Lock()
+	subs := r.subs
+	r.subs = nil
+	r.mu.Unlock()
+
+	for _, s := range subs {
+		close(s.done)
+	}
+
+	for _, s := range subs {
+		<-s.stopped
+	}
+}
+
+// notify wakes everything waiting on c. It must be called with r.mu held
+func (r *Recorder) notify(c Call) {
+	if r.changed != nil {
+		close(r.changed)
+		r.changed = nil
+	}
+
+	if s, ok := r.subs[c.Method]; ok {
+		s.push(c)
+	}
+}
+
+type subscription struct {
+	mu      sync.Mutex
+	queue   []Call
+	wake    chan struct{}
+	done    chan struct{} // closed to stop pump
+	stopped chan struct{} // closed once pump has stopped
+	ch      chan Call
+}
+
+func (s *subscription) push(c Call) {
+	s.mu.Lock()
+	s.queue = append(s.queue, c)
+	s.mu.Unlock()
+
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (s *subscription) pump() {
+	defer close(s.stopped)
+	defer close(s.ch)
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-s.wake:
+		}
+
+		for {
+			s.mu.Lock()
+			if len(s.queue) == 0 {
+				s.mu.Unlock()
+				break
+			}
+
+			c := s.queue[0]
+			s.queue = s.queue[1:]
+			s.mu.Unlock()
+
+			// a reader that's ready would otherwise race with done
+			select {
+			case <-s.done:
+				return
+			default:
+			}
+
+			select {
+			case <-s.done:
+				return
+			case s.ch <- c:
+			}
+		}
+	}
+}
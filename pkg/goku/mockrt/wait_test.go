@@ -0,0 +1,99 @@
+package mockrt
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWaitFor(t *testing.T) {
+	var r Recorder
+	go func() {
+		for i := range 3 {
+			r.Record("Put", i)
+			r.Record("Get", i)
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	for i := range 3 {
+		c, err := r.WaitFor(ctx, "Get")
+		if err != nil {
+			t.Fatalf("should not err waiting for call %d: %s", i, err)
+		}
+
+		if c.Args[0] != i {
+			t.Errorf("calls should come back in order, wanted %d but got %v", i, c.Args[0])
+		}
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := r.WaitFor(ctx, "Get"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("wanted deadline exceeded once calls run out, got %v", err)
+	}
+}
+
+func TestCalled(t *testing.T) {
+	var r Recorder
+	ch := r.Called("Get")
+	if ch != r.Called("Get") {
+		t.Errorf("every caller should get the same channel")
+	}
+
+	for i := range 100 {
+		r.Record("Get", i)
+		r.Record("Put", i)
+	}
+
+	for i := range 100 {
+		select {
+		case c := <-ch:
+			if c.Args[0] != i {
+				t.Fatalf("calls should come back in order, wanted %d but got %v", i, c.Args[0])
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for call %d", i)
+		}
+	}
+}
+
+func TestClose(mainTest *testing.T) {
+	closed := func(tt *testing.T, ch <-chan Call) {
+		tt.Helper()
+		select {
+		case _, ok := <-ch:
+			if ok {
+				tt.Error("undelivered calls should be dropped")
+			}
+		case <-time.After(time.Second):
+			tt.Error("the channel should be closed")
+		}
+	}
+
+	mainTest.Run("close", func(tt *testing.T) {
+		var r Recorder
+		ch := r.Called("Get")
+		r.Record("Get", 1)
+		r.Close()
+		closed(tt, ch)
+
+		if r.Called("Get") == ch {
+			tt.Error("Called after Close should start a new channel")
+		}
+		r.Close()
+	})
+
+	mainTest.Run("cleanup", func(tt *testing.T) {
+		var ch <-chan Call
+		tt.Run("mock", func(tt *testing.T) {
+			r := Recorder{T: tt}
+			ch = r.Called("Get")
+		})
+		closed(tt, ch)
+	})
+}
@@ -42,7 +42,7 @@ func (i *nodelist) AddFile(filename ...string) error {
 }
 
 func (i *nodelist) addNode(src string) error {
-	node, err := parser.ParseFile(i.fset, "", src, parser.AllErrors)
+	node, err := parser.ParseFile(i.fset, "", src, parser.AllErrors|parser.ParseComments)
 	if err != nil {
 		return err
 	}
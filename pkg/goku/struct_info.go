@@ -3,9 +3,13 @@ package goku
 import (
 	"bytes"
 	"fmt"
+	"go/ast"
 	"go/format"
+	"slices"
+	"strconv"
 	"strings"
 	"unicode"
+	"unicode/utf8"
 )
 
 type Import struct {
@@ -18,8 +22,14 @@ type StructContract struct {
 	StructName       string
 	StructTypeParams []TypeInfo
 	Methods          []MethodInfo
+
+	// types declared in the package, by name, so generators can see what a
+	// named type is made of
+	types map[string]ast.Expr
 }
 
+const mockrtPath = "github.com/AnthonyHewins/goku/pkg/goku/mockrt"
+
 type IfaceOpt func(*iface)
 
 type iface struct {
@@ -39,6 +49,9 @@ type iface struct {
 	PrivateMockImplementations []string
 	PublicMockImplementations  []string
 
+	// Render only the mock, to keep it out of the interface's file
+	MockOnly bool
+
 	typeAliases string
 	genPrivate  bool
 }
@@ -60,9 +73,31 @@ func (s StructContract) GenInterface(name string, opts ...IfaceOpt) ([]byte, err
 		v(&i)
 	}
 
+	return s.genIface(i)
+}
+
+// Generate just the mock of the interface named name, for a file of its own.
+// Mocks import testing, so a file ending in _test.go keeps that out of the
+// package's build
+func (s StructContract) GenMock(name, mockName string, opts ...IfaceOpt) ([]byte, error) {
+	i := iface{Name: name, Imports: s.Imports, PkgName: s.PkgName, Original: s.StructName}
+
+	for _, v := range opts {
+		v(&i)
+	}
+	i.MockName, i.MockOnly = mockName, true
+
+	return s.genIface(i)
+}
+
+func (s StructContract) genIface(i iface) ([]byte, error) {
+	if i.MockName != "" {
+		i.Imports = withImports(i.Imports, Import{Path: "context"}, Import{Path: mockrtPath}, Import{Path: "testing"})
+	}
+
 	if len(s.StructTypeParams) > 0 {
-		i.TypeParams = i.writeTypeParams(&s)
-		i.typeAliases = i.writeTypeAliases(&s)
+		i.TypeParams = typeParamList(s.StructTypeParams)
+		i.typeAliases = typeArgList(s.StructTypeParams)
 	}
 
 	for _, v := range s.Methods {
@@ -74,11 +109,11 @@ func (s StructContract) GenInterface(name string, opts ...IfaceOpt) ([]byte, err
 		case !unicode.IsLower(rune(v.Name[0])):
 			i.PublicMethods = append(i.PublicMethods, i.interfaceMethodStr(&v))
 			i.PublicMockFields = append(i.PublicMockFields, i.mockFieldFn(&v))
-			i.PublicMockImplementations = append(i.PublicMockImplementations, i.mockMethod(&v))
+			i.PublicMockImplementations = append(i.PublicMockImplementations, i.mockMethod(&v), i.mockAssertions(&v), i.mockWaiters(&v))
 		case i.genPrivate:
 			i.PrivateMethods = append(i.PrivateMethods, i.interfaceMethodStr(&v))
 			i.PrivateMockFields = append(i.PrivateMockFields, i.mockFieldFn(&v))
-			i.PrivateMockImplementations = append(i.PrivateMockImplementations, i.mockMethod(&v))
+			i.PrivateMockImplementations = append(i.PrivateMockImplementations, i.mockMethod(&v), i.mockAssertions(&v), i.mockWaiters(&v))
 		}
 	}
 
@@ -90,30 +125,22 @@ func (s StructContract) GenInterface(name string, opts ...IfaceOpt) ([]byte, err
 	return format.Source(b.Bytes())
 }
 
-func (i *iface) writeTypeParams(s *StructContract) string {
-	var typeParams strings.Builder
-	typeParams.WriteRune('[')
-	for i, v := range s.StructTypeParams {
-		typeParams.WriteString(v.String())
-		if i != len(s.StructTypeParams)-1 {
-			typeParams.WriteString(", ")
-		}
+// typeParamList renders type parameters as they're declared, e.g. [X any]
+func typeParamList(params []TypeInfo) string {
+	list := make([]string, len(params))
+	for i, v := range params {
+		list[i] = v.String()
 	}
-	typeParams.WriteRune(']')
-	return typeParams.String()
+	return "[" + strings.Join(list, ", ") + "]"
 }
 
-func (i *iface) writeTypeAliases(s *StructContract) string {
-	var typeAliases strings.Builder
-	typeAliases.WriteRune('[')
-	for i, v := range s.StructTypeParams {
-		typeAliases.WriteString(v.Name)
-		if i != len(s.StructTypeParams)-1 {
-			typeAliases.WriteString(", ")
-		}
+// typeArgList renders type parameters as they're passed, e.g. [X]
+func typeArgList(params []TypeInfo) string {
+	list := make([]string, len(params))
+	for i, v := range params {
+		list[i] = v.Name
 	}
-	typeAliases.WriteRune(']')
-	return typeAliases.String()
+	return "[" + strings.Join(list, ", ") + "]"
 }
 
 func (i *iface) interfaceMethodStr(m *MethodInfo) string {
@@ -121,16 +148,40 @@ func (i *iface) interfaceMethodStr(m *MethodInfo) string {
 		return ""
 	}
 
-	return m.Name + i.tuple(m)
+	return m.Name + tuple(m)
 }
 
 func (i *iface) mockMethod(m *MethodInfo) string {
-	var sb strings.Builder
-	sb.WriteString(fmt.Sprintf("func (mockImplementation %s%s) %s", i.MockName, i.typeAliases, m.Name))
+	names := resultNames(m)
+	args := make([]string, len(m.Arguments))
+	for idx, v := range m.Arguments {
+		args[idx] = v.Name
+	}
 
-	sb.WriteString(i.tuple(m))
+	record := strconv.Quote(m.Name)
+	if len(args) > 0 {
+		record += ", " + strings.Join(args, ", ")
+	}
 
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("func (mockImplementation *%s%s) %s", i.MockName, i.typeAliases, m.Name))
+	sb.WriteString(params(m))
+	sb.WriteString(results(m, names))
 	sb.WriteString(" {\n\t")
+
+	sb.WriteString(fmt.Sprintf("mockImplementation.Recorder.Record(%s)\n\t", record))
+	if ctx := m.ContextArg(); ctx != "" {
+		await := fmt.Sprintf("mockImplementation.Recorder.Await(%s, %q)", ctx, m.Name)
+		if m.ReturnsError() {
+			errResult := names[len(names)-1]
+			sb.WriteString(fmt.Sprintf("if %s = %s; %s != nil {\n\t\treturn\n\t}\n\t", errResult, await, errResult))
+		} else {
+			sb.WriteString(fmt.Sprintf("if %s != nil {\n\t\treturn\n\t}\n\t", await))
+		}
+	}
+	sb.WriteString(fmt.Sprintf("if mockImplementation.%sFn == nil {\n\t\t", m.Name))
+	sb.WriteString(fmt.Sprintf("mockImplementation.Recorder.Unexpected(%s)\n\t\treturn\n\t}\n\t", record))
+
 	if len(m.Returns) > 0 {
 		sb.WriteString("return ")
 	}
@@ -151,11 +202,86 @@ func (i *iface) mockMethod(m *MethodInfo) string {
 	return sb.String()
 }
 
+// mockAssertions renders typed wrappers over the recorder's assertions for m.
+// Arguments are typed any so callers can pass a value or a mockrt.Matcher
+func (i *iface) mockAssertions(m *MethodInfo) string {
+	taken := make(map[string]struct{}, len(m.Arguments))
+	params := make([]string, len(m.Arguments))
+	for idx, v := range m.Arguments {
+		taken[v.Name] = struct{}{}
+		params[idx] = v.Name
+	}
+
+	tb := freeName("t", taken)
+	recv := fmt.Sprintf("func (mockImplementation *%s%s) ", i.MockName, i.typeAliases)
+	call := fmt.Sprintf("{\n\t%s.Helper()\n\treturn mockImplementation.Recorder.", tb)
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%sAssert%sCalled(%s testing.TB", recv, m.Name, tb))
+	if len(params) > 0 {
+		sb.WriteString(", " + strings.Join(params, ", ") + " any")
+	}
+	sb.WriteString(fmt.Sprintf(") bool %sAssertCalled(%s, %q", call, tb, m.Name))
+	if len(params) > 0 {
+		sb.WriteString(", " + strings.Join(params, ", "))
+	}
+	sb.WriteString(")\n}\n\n")
+
+	sb.WriteString(fmt.Sprintf("%sAssert%sCalledTimes(%s testing.TB, n int) bool %sAssertCalledTimes(%s, %q, n)\n}\n\n", recv, m.Name, tb, call, tb, m.Name))
+	sb.WriteString(fmt.Sprintf("%sAssert%sNotCalled(%s testing.TB) bool %sAssertNotCalled(%s, %q)\n}", recv, m.Name, tb, call, tb, m.Name))
+
+	return sb.String()
+}
+
+// mockWaiters renders the typed call struct for m along with the methods
+// tests use to block until the mock is called
+func (i *iface) mockWaiters(m *MethodInfo) string {
+	callType := i.MockName + m.Name + "Call"
+	recv := fmt.Sprintf("func (mockImplementation *%s%s) ", i.MockName, i.typeAliases)
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("// %s holds the arguments of a call to %s\n", callType, m.Name))
+	sb.WriteString(fmt.Sprintf("type %s%s struct {", callType, i.TypeParams))
+	fields := make([]string, len(m.Arguments))
+	taken := make(map[string]struct{}, len(m.Arguments))
+	for idx, v := range m.Arguments {
+		// parameters like t and T export to the same name
+		fields[idx] = freeName(exportedName(v.Name), taken)
+		taken[fields[idx]] = struct{}{}
+		sb.WriteString(fmt.Sprintf("\n\t%s %s", fields[idx], strings.Replace(v.Type, "...", "[]", 1)))
+	}
+	sb.WriteString("\n}\n\n")
+
+	callType += i.typeAliases
+	sb.WriteString(fmt.Sprintf("%sWaitFor%s(ctx context.Context) (%s, error) {\n\t", recv, m.Name, callType))
+	c := "c"
+	if len(m.Arguments) == 0 {
+		c = "_"
+	}
+	sb.WriteString(fmt.Sprintf("%s, err := mockImplementation.Recorder.WaitFor(ctx, %q)\n\t", c, m.Name))
+	sb.WriteString(fmt.Sprintf("if err != nil {\n\t\treturn %s{}, err\n\t}\n\n\t", callType))
+	sb.WriteString(fmt.Sprintf("var call %s", callType))
+	for idx, v := range m.Arguments {
+		sb.WriteString(fmt.Sprintf("\n\tcall.%s, _ = c.Args[%d].(%s)", fields[idx], idx, strings.Replace(v.Type, "...", "[]", 1)))
+	}
+	sb.WriteString("\n\treturn call, nil\n}\n\n")
+
+	sb.WriteString(fmt.Sprintf("%s%sCalled() <-chan mockrt.Call {\n\t", recv, m.Name))
+	sb.WriteString(fmt.Sprintf("return mockImplementation.Recorder.Called(%q)\n}", m.Name))
+
+	return sb.String()
+}
+
 func (i *iface) mockFieldFn(m *MethodInfo) string {
-	return m.Name + "Fn func" + i.tuple(m)
+	return m.Name + "Fn func" + tuple(m)
 }
 
-func (i *iface) tuple(m *MethodInfo) string {
+// tuple renders the signature of m without the func keyword or name
+func tuple(m *MethodInfo) string {
+	return params(m) + results(m, nil)
+}
+
+func params(m *MethodInfo) string {
 	var sb strings.Builder
 	sb.WriteRune('(')
 	for idx, v := range m.Arguments {
@@ -165,14 +291,68 @@ func (i *iface) tuple(m *MethodInfo) string {
 		}
 	}
 	sb.WriteRune(')')
+	return sb.String()
+}
 
-	switch len(m.Returns) {
-	case 0:
-	case 1:
-		sb.WriteString(fmt.Sprintf(" %s", m.Returns[0]))
-	default:
-		sb.WriteString(fmt.Sprintf(" (%s)", strings.Join(m.Returns, ", ")))
+// results renders the return types of m, naming each one after the same
+// index in names when they're given
+func results(m *MethodInfo, names []string) string {
+	if len(names) == 0 {
+		switch len(m.Returns) {
+		case 0:
+			return ""
+		case 1:
+			return " " + m.Returns[0]
+		default:
+			return fmt.Sprintf(" (%s)", strings.Join(m.Returns, ", "))
+		}
 	}
 
-	return sb.String()
+	named := make([]string, len(m.Returns))
+	for idx, v := range m.Returns {
+		named[idx] = names[idx] + " " + v
+	}
+
+	return fmt.Sprintf(" (%s)", strings.Join(named, ", "))
+}
+
+// resultNames picks a name for each return value of m that doesn't collide
+// with any of its arguments, so generated bodies can use naked returns
+func resultNames(m *MethodInfo) []string {
+	taken := make(map[string]struct{}, len(m.Arguments))
+	for _, v := range m.Arguments {
+		taken[v.Name] = struct{}{}
+	}
+
+	names := make([]string, len(m.Returns))
+	for idx := range m.Returns {
+		names[idx] = freeName(fmt.Sprintf("r%d", idx), taken)
+	}
+
+	return names
+}
+
+// withImports appends each of extra to imports unless it's already there
+func withImports(imports []Import, extra ...Import) []Import {
+	imports = slices.Clip(imports)
+	for _, v := range extra {
+		if !slices.Contains(imports, v) {
+			imports = append(imports, v)
+		}
+	}
+	return imports
+}
+
+// exportedName capitalizes name so it can be used as an exported field
+func exportedName(name string) string {
+	r, size := utf8.DecodeRuneInString(name)
+	return string(unicode.ToUpper(r)) + name[size:]
+}
+
+// freeName prefixes name with underscores until it isn't taken
+func freeName(name string, taken map[string]struct{}) string {
+	for _, ok := taken[name]; ok; _, ok = taken[name] {
+		name = "_" + name
+	}
+	return name
 }
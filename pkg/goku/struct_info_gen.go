@@ -59,9 +59,29 @@ type MethodInfo struct {
 	TypeParams   []string
 	Arguments    []TypeInfo
 	Returns      []string
+	Directives   []Directive
+
+	// whether the first argument is a context.Context, resolved through the
+	// file's imports so it's found under any name context is imported as
+	takesContext bool
+}
+
+// Name of the first argument if it's a context.Context, otherwise empty
+func (m MethodInfo) ContextArg() string {
+	if len(m.Arguments) == 0 || !m.takesContext {
+		return ""
+	}
+	return m.Arguments[0].Name
+}
+
+// Whether the last value the method returns is an error
+func (m MethodInfo) ReturnsError() bool {
+	return len(m.Returns) > 0 && m.Returns[len(m.Returns)-1] == "error"
 }
 
 type pkgReaper struct {
+	fset          *token.FileSet
+	file          *ast.File
 	target        string
 	importAliases map[string]Import
 	usedAliases   map[string]struct{}
@@ -81,30 +101,19 @@ func (i *StructInfoGen) StructInfo() (*StructContract, error) {
 		StructName:       i.target,
 		StructTypeParams: []TypeInfo{},
 		Methods:          []MethodInfo{},
+		types:            map[string]ast.Expr{},
 	}
 
 	reaper := pkgReaper{
+		fset:          i.fset,
 		importAliases: map[string]Import{},
 		usedAliases:   map[string]struct{}{},
 		target:        i.target,
 	}
 
 	for _, node := range nodes {
-		for _, imp := range node.Imports {
-			path := strings.Trim(strings.TrimSpace(imp.Path.Value), `"`)
-			key := ""
-
-			i := Import{Path: path}
-			if imp.Name != nil {
-				i.Alias = imp.Name.Name
-				key = i.Alias
-			} else {
-				parts := strings.Split(path, "/")
-				key = parts[len(parts)-1]
-			}
-
-			reaper.importAliases[key] = i
-		}
+		reaper.file = node
+		reaper.addImports(node)
 
 		if want, got := info.PkgName, node.Name.Name; want != got {
 			return nil, fmt.Errorf("mismatched pkg name: wanted %s, got %s", want, got)
@@ -114,6 +123,7 @@ func (i *StructInfoGen) StructInfo() (*StructContract, error) {
 			switch x := decl.(type) {
 			case *ast.GenDecl:
 				info.StructTypeParams = append(info.StructTypeParams, reaper.descendGenDecl(x)...)
+				declaredTypes(x, info.types)
 			case *ast.FuncDecl:
 				m := reaper.descendFunc(x)
 				if m.Name != "" {
@@ -124,10 +134,40 @@ func (i *StructInfoGen) StructInfo() (*StructContract, error) {
 		}
 	}
 
-	info.Imports = make([]Import, len(reaper.usedAliases))
+	imports, err := reaper.imports()
+	if err != nil {
+		return nil, err
+	}
+	info.Imports = imports
+
+	return info, nil
+}
+
+func (p *pkgReaper) addImports(node *ast.File) {
+	for _, imp := range node.Imports {
+		path := strings.Trim(strings.TrimSpace(imp.Path.Value), `"`)
+		key := ""
+
+		i := Import{Path: path}
+		if imp.Name != nil {
+			i.Alias = imp.Name.Name
+			key = i.Alias
+		} else {
+			parts := strings.Split(path, "/")
+			key = parts[len(parts)-1]
+		}
+
+		p.importAliases[key] = i
+	}
+}
+
+// imports resolves every package name used in the target's signatures to
+// the import it came from
+func (p *pkgReaper) imports() ([]Import, error) {
+	imports := make([]Import, len(p.usedAliases))
 	idx := 0
-	for k := range reaper.usedAliases {
-		used := reaper.importAliases[k]
+	for k := range p.usedAliases {
+		used := p.importAliases[k]
 		if used.Path == "" {
 			return nil, fmt.Errorf("failed resolving package '%s': this package name is used in your source code but it doesn't"+
 				" match any import alias or basename in your import paths. This means that the basename of the import doesn't match the package name"+
@@ -136,11 +176,11 @@ func (i *StructInfoGen) StructInfo() (*StructContract, error) {
 				k,
 			)
 		}
-		info.Imports[idx] = used
+		imports[idx] = used
 		idx++
 	}
 
-	return info, nil
+	return imports, nil
 }
 
 func (p *pkgReaper) descendGenDecl(genDecl *ast.GenDecl) []TypeInfo {
@@ -174,6 +214,19 @@ func (p *pkgReaper) descendGenDecl(genDecl *ast.GenDecl) []TypeInfo {
 	return params
 }
 
+// declaredTypes adds the type declarations in genDecl to types, by name
+func declaredTypes(genDecl *ast.GenDecl, types map[string]ast.Expr) {
+	if genDecl.Tok != token.TYPE {
+		return
+	}
+
+	for _, spec := range genDecl.Specs {
+		if typeSpec, ok := spec.(*ast.TypeSpec); ok {
+			types[typeSpec.Name.Name] = typeSpec.Type
+		}
+	}
+}
+
 func (p *pkgReaper) descendFunc(funcDecl *ast.FuncDecl) MethodInfo {
 	if funcDecl.Recv == nil {
 		return MethodInfo{}
@@ -233,22 +286,96 @@ func (p *pkgReaper) descendFunc(funcDecl *ast.FuncDecl) MethodInfo {
 		}
 	}
 
-	for _, arg := range funcDecl.Type.Params.List {
+	method.Arguments, method.Returns = p.signature(funcDecl.Type)
+	method.takesContext = len(method.Arguments) > 0 && p.isContext(method.Arguments[0].Type)
+	method.Directives = p.directives(funcDecl, method.Arguments)
+
+	return method
+}
+
+// isContext reports whether t is context.Context, going by the package the
+// file imports it from rather than the name it's imported under
+func (p *pkgReaper) isContext(t string) bool {
+	pkg, name, ok := strings.Cut(t, ".")
+	if !ok {
+		pkg, name = ".", t
+	}
+	return name == "Context" && p.importAliases[pkg].Path == "context"
+}
+
+// signature reads the arguments and return types of a function. Arguments
+// that are unnamed or blank are given a name so generated code can pass
+// them along
+func (p *pkgReaper) signature(funcType *ast.FuncType) ([]TypeInfo, []string) {
+	var args []TypeInfo
+	taken := map[string]struct{}{}
+	for _, arg := range funcType.Params.List {
+		t := p.exprToString(arg.Type)
+		if len(arg.Names) == 0 {
+			args = append(args, TypeInfo{Type: t})
+			continue
+		}
+
 		for _, name := range arg.Names {
-			method.Arguments = append(method.Arguments, TypeInfo{
-				Name: name.Name,
-				Type: p.exprToString(arg.Type),
-			})
+			args = append(args, TypeInfo{Name: name.Name, Type: t})
+			taken[name.Name] = struct{}{}
 		}
 	}
 
-	if funcDecl.Type.Results != nil {
-		for _, result := range funcDecl.Type.Results.List {
-			method.Returns = append(method.Returns, p.exprToString(result.Type))
+	for idx, v := range args {
+		if v.Name == "" || v.Name == "_" {
+			args[idx].Name = freeName(fmt.Sprintf("arg%d", idx), taken)
 		}
 	}
 
-	return method
+	var returns []string
+	if funcType.Results != nil {
+		for _, result := range funcType.Results.List {
+			t := p.exprToString(result.Type)
+			for range max(len(result.Names), 1) {
+				returns = append(returns, t)
+			}
+		}
+	}
+
+	return args, returns
+}
+
+// fieldList renders a parameter, result or struct field list as written
+func (p *pkgReaper) fieldList(fields *ast.FieldList, sep string) string {
+	if fields == nil {
+		return ""
+	}
+
+	list := make([]string, len(fields.List))
+	for idx, field := range fields.List {
+		names := make([]string, len(field.Names))
+		for i, name := range field.Names {
+			names[i] = name.Name
+		}
+
+		list[idx] = p.exprToString(field.Type)
+		if len(names) > 0 {
+			list[idx] = strings.Join(names, ", ") + " " + list[idx]
+		}
+	}
+
+	return strings.Join(list, sep)
+}
+
+func (p *pkgReaper) funcTypeString(funcType *ast.FuncType) string {
+	s := "(" + p.fieldList(funcType.Params, ", ") + ")"
+
+	results := funcType.Results
+	switch {
+	case results == nil || len(results.List) == 0:
+	case len(results.List) == 1 && len(results.List[0].Names) == 0:
+		s += " " + p.exprToString(results.List[0].Type)
+	default:
+		s += " (" + p.fieldList(results, ", ") + ")"
+	}
+
+	return s
 }
 
 func (p *pkgReaper) exprToString(expr ast.Expr) string {
@@ -262,11 +389,50 @@ func (p *pkgReaper) exprToString(expr ast.Expr) string {
 		p.usedAliases[pkgImport] = struct{}{}
 		return pkgImport + "." + e.Sel.Name
 	case *ast.ArrayType:
+		if e.Len != nil {
+			return "[" + p.exprToString(e.Len) + "]" + p.exprToString(e.Elt)
+		}
 		return "[]" + p.exprToString(e.Elt)
+	case *ast.BasicLit:
+		return e.Value
 	case *ast.MapType:
 		return fmt.Sprintf("map[%s]%s", p.exprToString(e.Key), p.exprToString(e.Value))
 	case *ast.FuncType:
-		return "func"
+		return "func" + p.funcTypeString(e)
+	case *ast.ChanType:
+		switch e.Dir {
+		case ast.SEND:
+			return "chan<- " + p.exprToString(e.Value)
+		case ast.RECV:
+			return "<-chan " + p.exprToString(e.Value)
+		default:
+			return "chan " + p.exprToString(e.Value)
+		}
+	case *ast.InterfaceType:
+		if len(e.Methods.List) == 0 {
+			return "interface{}"
+		}
+
+		methods := make([]string, len(e.Methods.List))
+		for idx, m := range e.Methods.List {
+			if f, ok := m.Type.(*ast.FuncType); ok && len(m.Names) > 0 {
+				methods[idx] = m.Names[0].Name + p.funcTypeString(f)
+			} else {
+				methods[idx] = p.exprToString(m.Type)
+			}
+		}
+		return "interface{ " + strings.Join(methods, "; ") + " }"
+	case *ast.StructType:
+		if len(e.Fields.List) == 0 {
+			return "struct{}"
+		}
+		return "struct{ " + p.fieldList(e.Fields, "; ") + " }"
+	case *ast.ParenExpr:
+		return "(" + p.exprToString(e.X) + ")"
+	case *ast.UnaryExpr:
+		return e.Op.String() + p.exprToString(e.X)
+	case *ast.BinaryExpr:
+		return p.exprToString(e.X) + " " + e.Op.String() + " " + p.exprToString(e.Y)
 	case *ast.IndexExpr:
 		return fmt.Sprintf("%s[%s]", p.exprToString(e.X), p.exprToString(e.Index))
 	case *ast.IndexListExpr:
@@ -25,6 +25,18 @@ type X[Y any, Z int] struct{}
 func (x X[Y,Z]) L(tt X, r Y) (Y) { var y Y; return y}
 `
 
+const blankArgs = `package x
+type X struct{}
+func (x X) L(_ int, _ string) (a, b int) {return 0, 0}
+func (x X) M(int, string) {}
+`
+
+const unnamedArgs = `package x
+type X struct{}
+func (x X) L(_ int, _ string, cb func(n int) error, ch <-chan struct{}) (a, b int) {return 0, 0}
+func (x X) M(int, [2]string) {}
+`
+
 const invalidPkgImport = `package x
 import "invalid/pkgname"
 type X struct{}
@@ -115,6 +127,64 @@ func Test(mainTest *testing.T) {
 				},
 			},
 		},
+		{
+			name: "blankArgs",
+			arg:  blankArgs,
+			expected: StructContract{
+				PkgName:          "x",
+				StructName:       "X",
+				StructTypeParams: []TypeInfo{},
+				Methods: []MethodInfo{
+					{
+						Name:         "L",
+						ReceiverType: "X",
+						Arguments: []TypeInfo{
+							{"arg0", "int"},
+							{"arg1", "string"},
+						},
+						Returns: []string{"int", "int"},
+					},
+					{
+						Name:         "M",
+						ReceiverType: "X",
+						Arguments: []TypeInfo{
+							{"arg0", "int"},
+							{"arg1", "string"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "unnamedArgs",
+			arg:  unnamedArgs,
+			expected: StructContract{
+				PkgName:          "x",
+				StructName:       "X",
+				StructTypeParams: []TypeInfo{},
+				Methods: []MethodInfo{
+					{
+						Name:         "L",
+						ReceiverType: "X",
+						Arguments: []TypeInfo{
+							{"arg0", "int"},
+							{"arg1", "string"},
+							{"cb", "func(n int) error"},
+							{"ch", "<-chan struct{}"},
+						},
+						Returns: []string{"int", "int"},
+					},
+					{
+						Name:         "M",
+						ReceiverType: "X",
+						Arguments: []TypeInfo{
+							{"arg0", "int"},
+							{"arg1", "[2]string"},
+						},
+					},
+				},
+			},
+		},
 		{
 			name:        "correctly finds err in pkg import",
 			arg:         invalidPkgImport,
@@ -167,3 +237,48 @@ func Test(mainTest *testing.T) {
 		})
 	}
 }
+
+func TestContextArg(mainTest *testing.T) {
+	testCases := []struct {
+		name     string
+		src      string
+		expected string
+	}{
+		{
+			name:     "context",
+			src:      "package x\nimport \"context\"\ntype X struct{}\nfunc (x X) L(ctx context.Context) {}",
+			expected: "ctx",
+		},
+		{
+			name:     "aliased",
+			src:      "package x\nimport stdctx \"context\"\ntype X struct{}\nfunc (x X) L(c stdctx.Context) {}",
+			expected: "c",
+		},
+		{
+			name: "another package's Context",
+			src:  "package x\nimport \"github.com/x/context\"\ntype X struct{}\nfunc (x X) L(ctx context.Context) {}",
+		},
+		{
+			name: "not first",
+			src:  "package x\nimport \"context\"\ntype X struct{}\nfunc (x X) L(id int, ctx context.Context) {}",
+		},
+	}
+
+	for _, tc := range testCases {
+		mainTest.Run(tc.name, func(tt *testing.T) {
+			i := NewStructInfoGen("X")
+			if err := i.AddSrc(tc.src); err != nil {
+				tt.Fatalf("src code is not valid in test %s", err)
+			}
+
+			got, err := i.StructInfo()
+			if err != nil {
+				tt.Fatalf("should not error, got %s", err)
+			}
+
+			if ctx := got.Methods[0].ContextArg(); ctx != tc.expected {
+				tt.Errorf("wanted %q but got %q", tc.expected, ctx)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package goku
+
+import (
+	"context"
+	"time"
+)
+
+type User struct{ ID int }
+
+type Store struct{}
+
+//goku:trace id
+//goku:timeout 1500ms
+//goku:cache ttl=30s
+//goku:singleflight
+func (s *Store) Get(ctx context.Context, id int) (*User, error) { return nil, nil }
+
+//goku:redact password
+//goku:log user results=false
+func (s *Store) Login(ctx context.Context, user string, password string) (string, error) {
+	return "", nil
+}
+
+//goku:noretry
+//goku:noshadow
+//goku:authz role=admin
+func (s *Store) Save(
+	ctx context.Context,
+	u User,
+	secret string, //goku:redact
+) error {
+	return nil
+}
+
+//goku:trace ids
+//goku:cache ttl=1m size=10
+//goku:wrap ids=%d
+//goku:authz role=admin,viewer
+func (s *Store) List(ctx context.Context, ids ...int) ([]User, error) { return nil, nil }
+
+//goku:read
+//goku:singleflight
+//goku:cache ttl=5s
+//goku:noauthz
+func (s *Store) Count() int { return 0 }
+
+//goku:trace time
+//goku:noauthz
+func (s *Store) Touch(time time.Time, start string) {}
+
+//goku:read
+//goku:noauthz
+//goku:singleflight
+func (s *Store) Exists(ctx context.Context, id int) bool { return false }
@@ -0,0 +1,15 @@
+package goku
+
+import "context"
+
+// Client has nothing but methods that can fail, for decorators that require it
+type Client struct{}
+
+//goku:batch FetchMany wait=2ms size=50
+func (c *Client) Fetch(ctx context.Context, key string) ([]byte, error) { return nil, nil }
+
+func (c *Client) FetchMany(ctx context.Context, keys ...string) ([][]byte, error) { return nil, nil }
+
+func (c *Client) Put(ctx context.Context, key string, value []byte) error { return nil }
+
+func (c *Client) Ping() error { return nil }
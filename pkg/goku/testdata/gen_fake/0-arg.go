@@ -0,0 +1,9 @@
+package goku
+
+import (
+	"context"
+	"time"
+)
+
+//go:generate go run ../../../../cmd/goku fake Retrier -o 0-expected.txt
+type Retrier func(ctx context.Context, attempt int, _ time.Duration, opts ...string) (time.Duration, error)
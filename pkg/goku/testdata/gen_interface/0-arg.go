@@ -20,3 +20,4 @@ func (m Target[X]) Import(d context.Context)                     {}
 func (m Target[X]) ImportAlias(d synco.Map)                      {}
 func (m Target[X]) Maps(d map[*template.Template]X)              {}
 func (m Target[X]) Ellipses(d ...int)                            {}
+func (m Target[X]) Clash(t, T int)                               {}